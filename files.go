@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"main.go/pkg/podlint"
+)
+
+// Является ли файл YAML-манифестом по расширению
+func isYAMLFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// Каталог, с которого начинается обход для шаблона: часть пути до первого метасимвола
+func globRoot(pattern string) string {
+	segments := strings.Split(filepath.ToSlash(pattern), "/")
+	for i, seg := range segments {
+		if strings.ContainsAny(seg, "*?[") {
+			if i == 0 {
+				return "."
+			}
+			root := strings.Join(segments[:i], "/")
+			if root == "" {
+				return "/"
+			}
+			return filepath.FromSlash(root)
+		}
+	}
+	return filepath.Dir(pattern)
+}
+
+// Источник файлов на диске: путь, каталог или шаблон из командной строки
+type fsSource struct {
+	arg       string
+	recursive bool
+}
+
+func newFSSource(arg string, recursive bool) (podlint.Source, error) {
+	return fsSource{arg, recursive}, nil
+}
+
+func (s fsSource) Files() ([]string, error) {
+	return findFiles([]string{s.arg}, s.recursive)
+}
+
+func (s fsSource) Open(name string) (io.ReadCloser, error) {
+	return os.Open(name)
+}
+
+// Источник из стандартного ввода, аргумент "-"
+type stdinSource struct{}
+
+// Имя, под которым stdin выводится в отчётах
+const stdinName = "<stdin>"
+
+func newStdinSource(string, bool) (podlint.Source, error) {
+	return stdinSource{}, nil
+}
+
+func (stdinSource) Files() ([]string, error) {
+	return []string{stdinName}, nil
+}
+
+func (stdinSource) Open(string) (io.ReadCloser, error) {
+	return io.NopCloser(os.Stdin), nil
+}
+
+// Файл для проверки и источник, из которого он читается
+type inputFile struct {
+	name   string
+	source podlint.Source
+}
+
+// Раскрытие аргументов командной строки в файлы их источников (см.
+// podlint.OpenSource); файл, который встретился повторно, пропускается
+func collectInputs(args []string, recursive bool) ([]inputFile, error) {
+	var inputs []inputFile
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		src, err := podlint.OpenSource(arg, recursive)
+		if err != nil {
+			return nil, err
+		}
+		names, err := src.Files()
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				inputs = append(inputs, inputFile{name, src})
+			}
+		}
+	}
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("no YAML files found")
+	}
+	return inputs, nil
+}
+
+// Проверяется ровно один файл, и он указан аргументом сам, а не найден в
+// каталоге или по шаблону. Аргумент "-" сравнивается с именем stdinName,
+// под которым stdin попадает в список файлов.
+func singleInput(files []inputFile, args []string) bool {
+	if len(files) != 1 || len(args) != 1 {
+		return false
+	}
+	name := args[0]
+	if podlint.SourceScheme(name) == podlint.SchemeStdin {
+		name = stdinName
+	}
+	return files[0].name == name
+}
+
+// Раскрытие аргументов командной строки в список файлов.
+// Файлы берутся как есть, каталоги дают YAML-файлы (с подкаталогами при
+// recursive), шаблоны с "*", "?", "[" и "**" раскрываются обходом каталогов.
+// Пустой результат не ошибка: её сообщает collectInputs по всем аргументам.
+func findFiles(args []string, recursive bool) ([]string, error) {
+	var files []string
+	seen := make(map[string]bool)
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+
+	for _, arg := range args {
+		if strings.ContainsAny(arg, "*?[") {
+			pattern := filepath.Clean(arg)
+			var matched []string
+			err := filepath.WalkDir(globRoot(pattern), func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if !d.IsDir() && podlint.MatchGlob(pattern, path) {
+					matched = append(matched, path)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", arg, err)
+			}
+			if len(matched) == 0 {
+				return nil, fmt.Errorf("%s: no files match pattern", arg)
+			}
+			sort.Strings(matched)
+			for _, path := range matched {
+				add(path)
+			}
+			continue
+		}
+
+		info, err := os.Stat(arg)
+		if err != nil || !info.IsDir() {
+			// Ошибка чтения будет выведена при проверке файла
+			add(arg)
+			continue
+		}
+
+		var found []string
+		err = filepath.WalkDir(arg, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != arg && !recursive {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if isYAMLFile(path) {
+				found = append(found, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", arg, err)
+		}
+		sort.Strings(found)
+		for _, path := range found {
+			add(path)
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"main.go/pkg/podlint"
+)
+
+func TestGlobRoot(t *testing.T) {
+	tests := []struct {
+		pattern, want string
+	}{
+		{"*.yaml", "."},
+		{"**/*.yaml", "."},
+		{"manifests/*.yaml", "manifests"},
+		{"manifests/apps/**/pod-?.yml", filepath.FromSlash("manifests/apps")},
+		{"manifests/[ab]/*.yaml", "manifests"},
+		{"/*.yaml", "/"},
+		{"/srv/k8s/**", filepath.FromSlash("/srv/k8s")},
+		{"manifests/pod.yaml", "manifests"},
+	}
+	for _, tt := range tests {
+		if got := globRoot(filepath.FromSlash(tt.pattern)); got != tt.want {
+			t.Errorf("globRoot(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestFindFiles(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.yaml":             "",
+		"b.yml":              "",
+		"notes.txt":          "",
+		"apps/web.yaml":      "",
+		"apps/deep/job.YAML": "",
+		"apps/deep/x.json":   "",
+	})
+	path := func(names ...string) []string {
+		var paths []string
+		for _, name := range names {
+			paths = append(paths, filepath.Join(dir, filepath.FromSlash(name)))
+		}
+		return paths
+	}
+	tests := []struct {
+		name      string
+		args      []string
+		recursive bool
+		want      []string
+	}{
+		{"directory", path("."), false, path("a.yaml", "b.yml")},
+		{"recursive", path("."), true, path("a.yaml", "apps/deep/job.YAML", "apps/web.yaml", "b.yml")},
+		{"file as is", path("notes.txt"), false, path("notes.txt")},
+		{"missing file is kept", path("missing.yaml"), false, path("missing.yaml")},
+		{"glob", path("*.y*ml"), false, path("a.yaml", "b.yml")},
+		{"double star", path("**/*.yaml"), false, path("a.yaml", "apps/web.yaml")},
+		{"duplicates", append(path("a.yaml"), path(".", "*.yaml")...), false, path("a.yaml", "b.yml")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findFiles(tt.args, tt.recursive)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := findFiles(path("*.json"), false); err == nil || !strings.Contains(err.Error(), "no files match pattern") {
+		t.Errorf("unmatched glob: err = %v", err)
+	}
+}
+
+func TestCollectInputs(t *testing.T) {
+	podlint.RegisterSource("", newFSSource)
+	podlint.RegisterSource(podlint.SchemeStdin, newStdinSource)
+	dir := writeFiles(t, map[string]string{"a.yaml": "", "b.yaml": "", "sub/c.yaml": ""})
+
+	inputs, err := collectInputs([]string{filepath.Join(dir, "b.yaml"), dir, "-", "-"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, in := range inputs {
+		names = append(names, in.name)
+	}
+	// Повторы пропускаются, порядок — как в аргументах
+	want := []string{filepath.Join(dir, "b.yaml"), filepath.Join(dir, "a.yaml"), filepath.Join(dir, "sub", "c.yaml"), stdinName}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("got %q, want %q", names, want)
+	}
+	if _, ok := inputs[len(inputs)-1].source.(stdinSource); !ok {
+		t.Errorf("stdin input has source %T", inputs[len(inputs)-1].source)
+	}
+
+	if _, err := collectInputs([]string{t.TempDir()}, false); err == nil || err.Error() != "no YAML files found" {
+		t.Errorf("empty directory: err = %v", err)
+	}
+	if _, err := collectInputs([]string{"ftp://example.com/pod.yaml"}, false); err == nil || !strings.Contains(err.Error(), "unsupported source 'ftp'") {
+		t.Errorf("unknown scheme: err = %v", err)
+	}
+}
+
+func TestSingleInput(t *testing.T) {
+	file := func(names ...string) []inputFile {
+		var files []inputFile
+		for _, name := range names {
+			files = append(files, inputFile{name: name})
+		}
+		return files
+	}
+	tests := []struct {
+		name  string
+		files []inputFile
+		args  []string
+		want  bool
+	}{
+		{"file", file("pod.yaml"), []string{"pod.yaml"}, true},
+		{"stdin", file(stdinName), []string{"-"}, true},
+		{"directory", file("dir/pod.yaml"), []string{"dir"}, false},
+		{"two files", file("a.yaml", "b.yaml"), []string{"a.yaml", "b.yaml"}, false},
+	}
+	for _, tt := range tests {
+		if got := singleInput(tt.files, tt.args); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
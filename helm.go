@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"main.go/pkg/podlint"
+)
+
+// Список флагов, которые можно указать несколько раз: -f a.yaml -f b.yaml
+type stringsFlag []string
+
+func (s *stringsFlag) String() string     { return strings.Join(*s, ",") }
+func (s *stringsFlag) Set(v string) error { *s = append(*s, v); return nil }
+
+// Подкоманда helm: рендеринг чарта через helm template и проверка всех
+// документов. Нарушения относятся к шаблону, из которого получен документ
+// (по комментарию "# Source:"), строки — к отрендеренному тексту шаблона.
+func runHelm(args []string) int {
+	fs := flag.NewFlagSet("helm", flag.ContinueOnError)
+	var values, sets stringsFlag
+	fs.Var(&values, "f", "values file passed to helm template (repeatable)")
+	fs.Var(&sets, "set", "value override passed to helm template, e.g. image.tag=1.2 (repeatable)")
+	release := fs.String("release", "release", "release name used for rendering")
+	namespace := fs.String("namespace", "", "namespace used for rendering")
+	config := fs.String("config", "", "configuration file (default "+podlint.DefaultConfigFile+" in the current directory, if present)")
+	env := fs.String("env", "", "environment from the environments block of the configuration, e.g. prod")
+	output := fs.String("output", "text", "report format: text or json")
+
+	// Путь к чарту можно указать и до флагов: yamlvalid helm ./chart -f values.yaml
+	var chart []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return 2
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		chart = append(chart, args[0])
+		args = args[1:]
+	}
+	if len(chart) != 1 {
+		fmt.Println("Usage: yamlvalid helm <chart> [-f values.yaml]... [--set key=value]... [--release name] [--namespace ns]")
+		return 2
+	}
+	if *output != "text" && *output != "json" {
+		fmt.Printf("unknown output format '%s', expected text or json\n", *output)
+		return 2
+	}
+
+	opts := podlint.DefaultOptions()
+	if err := loadConfig(*config, *env, &opts); err != nil {
+		fmt.Printf("config: %v\n", err)
+		return 2
+	}
+
+	helmArgs := []string{"template", *release, chart[0]}
+	for _, v := range values {
+		helmArgs = append(helmArgs, "-f", v)
+	}
+	for _, s := range sets {
+		helmArgs = append(helmArgs, "--set", s)
+	}
+	if *namespace != "" {
+		helmArgs = append(helmArgs, "--namespace", *namespace)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("helm", helmArgs...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			fmt.Println("helm: the helm binary was not found in PATH")
+			return 2
+		}
+		fmt.Printf("helm template failed: %v\n%s", err, stderr.String())
+		return 2
+	}
+
+	var results []fileResult
+	templates, err := splitRendered(stdout.Bytes(), opts)
+	if err != nil {
+		// Вывод не разобрался: ошибка относится ко всему выводу helm template
+		res := podlint.ValidateFile(renderedName, stdout.Bytes(), opts)
+		results = append(results, fileResult{file: renderedName, findings: res.Errors})
+	}
+	for _, t := range templates {
+		res := podlint.ValidateFile(t.source, []byte(t.text), opts)
+		results = append(results, fileResult{file: t.source, docs: describeDocs(res.Documents), findings: res.Errors})
+	}
+
+	if *output == "json" {
+		err = writeJSON(os.Stdout, results, podlint.SeverityError)
+	} else {
+		err = writeText(os.Stdout, results, podlint.SeverityError, true, false, false)
+	}
+	if err != nil {
+		fmt.Println(err)
+		return 2
+	}
+	if shouldFail(results, podlint.SeverityError) {
+		return 1
+	}
+	return 0
+}
+
+// Отрендеренный текст одного шаблона
+type renderedTemplate struct {
+	source string // путь шаблона в чарте, например web/templates/deployment.yaml
+	text   string
+}
+
+// Имя для документов без комментария "# Source:" и для вывода, который не
+// удалось разобрать
+const renderedName = "rendered"
+
+// Разбор вывода helm template на шаблоны по комментариям "# Source: ...".
+// Границы документов определяет podlint.Parse, так что "---" внутри блочных
+// строк не разрывает документ. Документы одного шаблона собираются вместе в
+// порядке вывода, пустые документы пропускаются.
+func splitRendered(out []byte, opts podlint.Options) ([]renderedTemplate, error) {
+	docs, err := podlint.Parse(out, opts)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.SplitAfter(string(out), "\n")
+
+	var templates []renderedTemplate
+	index := make(map[string]int)
+	for i, doc := range docs {
+		if len(doc.Node.Content) == 0 || doc.Node.Content[0].Tag == "!!null" {
+			continue
+		}
+		// Строки документа: от его начала (без маркера "---") до начала следующего
+		start, end := doc.Node.Line-1, len(lines)
+		if i+1 < len(docs) {
+			end = docs[i+1].Node.Line - 1
+		}
+		if start < end && strings.HasPrefix(lines[start], "---") {
+			start++
+		}
+		text := strings.Join(lines[start:end], "")
+
+		source := renderedName
+		for _, line := range lines[start:end] {
+			if path, ok := strings.CutPrefix(strings.TrimSpace(line), "# Source: "); ok {
+				source = path
+				break
+			}
+		}
+		j, ok := index[source]
+		if !ok {
+			j = len(templates)
+			index[source] = j
+			templates = append(templates, renderedTemplate{source: source})
+		} else {
+			templates[j].text += "---\n"
+		}
+		templates[j].text += text
+		if !strings.HasSuffix(templates[j].text, "\n") {
+			templates[j].text += "\n"
+		}
+	}
+	return templates, nil
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"main.go/pkg/podlint"
+)
+
+// Вывод helm template: шаблон из двух документов, документ без
+// комментария "# Source:" и пустой шаблон
+const rendered = `---
+# Source: web/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: release-web
+spec:
+  selector: {app: web}
+  ports:
+    - port: 80
+---
+# Source: web/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: release-web
+spec:
+  selector:
+    matchLabels: {app: web}
+  template:
+    metadata:
+      labels: {app: web}
+    spec:
+      containers:
+        - name: web
+          image: nginx
+---
+# Source: web/templates/deployment.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: Bad Name
+data:
+  banner: |
+    ---
+    welcome
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: extra
+---
+# Source: web/templates/empty.yaml
+`
+
+func TestSplitRendered(t *testing.T) {
+	templates, err := splitRendered([]byte(rendered), podlint.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sources []string
+	for _, tmpl := range templates {
+		sources = append(sources, tmpl.source)
+	}
+	want := []string{"web/templates/service.yaml", "web/templates/deployment.yaml", renderedName}
+	if strings.Join(sources, ",") != strings.Join(want, ",") {
+		t.Fatalf("sources %v, want %v", sources, want)
+	}
+
+	deployment := templates[1].text
+	if !strings.HasPrefix(deployment, "# Source: web/templates/deployment.yaml\napiVersion: apps/v1\n") ||
+		strings.Count(deployment, "\n---\n") != 1 || !strings.Contains(deployment, "    ---\n    welcome\n") {
+		t.Errorf("unexpected deployment.yaml text:\n%s", deployment)
+	}
+	res := podlint.ValidateFile(templates[1].source, []byte(deployment), podlint.DefaultOptions())
+	if len(res.Documents) != 2 {
+		t.Errorf("deployment.yaml: %d documents, want 2", len(res.Documents))
+	}
+}
+
+// Подмена helm в PATH скриптом script
+func fakeHelm(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake helm is a shell script")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "helm"), []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return dir
+}
+
+// Код возврата runHelm(args) и его вывод
+func captureHelm(t *testing.T, args ...string) (int, string) {
+	t.Helper()
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		out, _ := io.ReadAll(r)
+		done <- string(out)
+	}()
+	code := runHelm(args)
+	w.Close()
+	os.Stdout = stdout
+	return code, <-done
+}
+
+func TestRunHelm(t *testing.T) {
+	dir := fakeHelm(t, "echo \"$@\" > \"$(dirname \"$0\")/args\"\ncat <<'EOF'\n"+rendered+"EOF\n")
+
+	code, out := captureHelm(t, "./chart", "-f", "values.yaml", "--set", "image.tag=1.2", "--release", "demo", "--namespace", "prod")
+	if code != 1 {
+		t.Errorf("exit code %d, want 1\n%s", code, out)
+	}
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.TrimSpace(string(args)), "template demo ./chart -f values.yaml --set image.tag=1.2 --namespace prod"; got != want {
+		t.Errorf("helm args %q, want %q", got, want)
+	}
+	for _, line := range []string{
+		"web/templates/deployment.yaml:15 image has invalid format 'nginx'",
+		"web/templates/deployment.yaml:21 name 'Bad Name' must be a DNS-1123 subdomain",
+		"web/templates/service.yaml: OK",
+		"rendered: OK",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("output does not contain %q:\n%s", line, out)
+		}
+	}
+}
+
+func TestRunHelmErrors(t *testing.T) {
+	fakeHelm(t, "echo 'Error: values don'\\''t meet the specifications of the schema(s)' >&2\nexit 1\n")
+	if code, out := captureHelm(t, "./chart"); code != 2 || !strings.Contains(out, "helm template failed") || !strings.Contains(out, "specifications of the schema") {
+		t.Errorf("failing helm: exit code %d, output:\n%s", code, out)
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	if code, out := captureHelm(t, "./chart"); code != 2 || !strings.Contains(out, "helm binary was not found") {
+		t.Errorf("missing helm: exit code %d, output:\n%s", code, out)
+	}
+	if code, _ := captureHelm(t); code != 2 {
+		t.Errorf("no chart: exit code %d, want 2", code)
+	}
+}
@@ -1,145 +1,408 @@
 package main
 
 import (
+	"bytes"
+	"flag"
 	"fmt"
+	"io"
 	"os"
-	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
 
-	"gopkg.in/yaml.v3"
+	"main.go/pkg/podlint"
 )
 
-// Проверка диапазона порта
-func validatePort(value interface{}) bool {
-	switch v := value.(type) {
-	case int:
-		return v > 0 && v < 65536
-	case int64:
-		return v > 0 && v < 65536
-	case float64:
-		return int(v) > 0 && int(v) < 65536
-	default:
-		return false
-	}
+// Параметры запуска валидатора
+type options struct {
+	lint   podlint.Options // параметры самой проверки
+	config string          // путь к файлу конфигурации
+	env    string          // окружение из блока environments конфигурации
+
+	recursive   bool // обход подкаталогов для аргументов-каталогов
+	concurrency int  // сколько файлов проверяется параллельно
+
+	output string // формат отчёта: text, json, sarif, junit или kubeconform-json
+	column bool   // колонка в текстовом отчёте: "<файл>:<строка>:<колонка>"
+	source bool   // строка манифеста с указателем под каждым нарушением в текстовом отчёте
+	failOn string // с какого уровня нарушений код выхода 1: error, warning или none
+
+	groupBy     string // группировка текстового отчёта: file или rule
+	summaryOnly bool   // в JSON-отчёте только итоги по правилам и файлам
+
+	showSuppressed bool // выводить нарушения, подавленные первопричиной (text и json)
+	verbose        bool // подробности по файлам в stderr, например исходная кодировка
+
+	requireRules string // правила через запятую, которые не должны быть отключены
+	k8sVersion   string // диапазон версий кластеров, например 1.27-1.29
+
+	// Webhook для уведомления о неуспешной проверке и формат тела: json или slack
+	notifyWebhook string
+	notifyFormat  string
+
+	networkTimeout time.Duration // ограничение времени одного сетевого запроса
+	offline        bool          // без сетевых запросов: URL и уведомления запрещены
 }
 
-// Основная функция проверки YAML
-func validateYAML(filename string) {
-	data, err := os.ReadFile(filename) // ✅ заменили ioutil.ReadFile
+// Буферы чтения файлов переиспользуются между файлами пакетной проверки
+var readBuffers = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
+// Буферы крупнее не возвращаются в пул, чтобы один большой файл не
+// удерживал память до конца проверки
+const maxPooledBuffer = 1 << 20
+
+// Чтение и проверка одного файла из его источника. Файл читается не больше лимита размера,
+// чтобы не загружать в память заведомо слишком большие входные данные.
+// Содержимое файла сохраняется в результате только при keepData, подавленные
+// нарушения — только при keepSuppressed. При verbose в stderr сообщается
+// кодировка файла, если его пришлось преобразовать.
+func validateFile(in inputFile, opts podlint.Options, keepData, keepSuppressed, verbose bool) fileResult {
+	filename := in.name
+	f, err := in.source.Open(filename)
 	if err != nil {
-		fmt.Printf("%s: unable to read file: %v\n", filename, err)
-		return
+		return fileResult{file: filename, findings: inputFailure(filename, err)}
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if opts.MaxFileSize > 0 {
+		r = io.LimitReader(f, opts.MaxFileSize+1)
+	}
+	buf := readBuffers.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			readBuffers.Put(buf)
+		}
+	}()
+	if _, err := buf.ReadFrom(r); err != nil {
+		return fileResult{file: filename, findings: inputFailure(filename, err)}
+	}
+	if opts.MaxFileSize > 0 && int64(buf.Len()) > opts.MaxFileSize {
+		// Файл дочитан не целиком: проверять обрезанный текст нельзя
+		return fileResult{file: filename, findings: []podlint.ValidationError{{
+			File:     filename,
+			RuleID:   podlint.RuleInput,
+			Message:  fmt.Sprintf("file size exceeds limit of %d bytes", opts.MaxFileSize),
+			Severity: podlint.SeverityError,
+		}}}
+	}
+
+	res := podlint.ValidateFile(filename, buf.Bytes(), opts)
+	result := fileResult{file: filename, docs: describeDocs(res.Documents), findings: res.Errors, disabled: res.Disabled}
+	if keepData {
+		// Фрагменты выводятся из того же текста, что разбирался
+		data, _, err := podlint.Normalize(buf.Bytes())
+		if err == nil {
+			result.data = bytes.Clone(data)
+		}
+	}
+	if verbose && res.Encoding != podlint.EncodingUTF8 {
+		fmt.Fprintf(os.Stderr, "%s: input is %s, converted to UTF-8 with LF line endings\n", filename, res.Encoding)
+	}
+	if keepSuppressed {
+		result.suppressed = res.Suppressed
+	}
+	return result
+}
+
+// Проверка файлов в opts.concurrency потоков. Результат i-го файла
+// записывается в i-й элемент, поэтому порядок отчёта не зависит от того,
+// в каком порядке потоки закончили работу.
+func validateFiles(files []inputFile, opts options) []fileResult {
+	results := make([]fileResult, len(files))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < opts.concurrency && w < len(files); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = validateFile(files[i], opts.lint, opts.source, opts.showSuppressed, opts.verbose)
+			}
+		}()
+	}
+	for i := range files {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results
+}
+
+// Загрузка конфигурации path и окружения env в opts. Явно указанный файл
+// конфигурации обязан существовать, файл по умолчанию — нет.
+func loadConfig(path, env string, opts *podlint.Options) error {
+	if path == "" {
+		if _, err := os.Stat(podlint.DefaultConfigFile); err == nil {
+			path = podlint.DefaultConfigFile
+		}
+	}
+	if path == "" {
+		if env != "" {
+			return fmt.Errorf("environment '%s' requires a configuration file with an environments block", env)
+		}
+		return nil
+	}
+	cfg, err := podlint.LoadConfig(path)
+	if err == nil {
+		err = cfg.Apply(opts)
 	}
+	if err == nil && env != "" {
+		err = cfg.ApplyEnvironment(env, opts)
+	}
+	return err
+}
+
+// Нарушение для файла, который не удалось прочитать
+func inputFailure(filename string, err error) []podlint.ValidationError {
+	return []podlint.ValidationError{{
+		File:     filename,
+		RuleID:   podlint.RuleInput,
+		Message:  fmt.Sprintf("unable to read file: %v", err),
+		Severity: podlint.SeverityError,
+	}}
+}
 
-	var raw map[string]interface{}
-	if err := yaml.Unmarshal(data, &raw); err != nil {
-		fmt.Printf("YAML decode error: %v\n", err)
-		return
+func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			os.Exit(runMigrate(os.Args[2:]))
+		case "helm":
+			os.Exit(runHelm(os.Args[2:]))
+		case "serve":
+			os.Exit(runServe(os.Args[2:]))
+		case "serve-webhook":
+			os.Exit(runServeWebhook(os.Args[2:]))
+		}
 	}
 
-	base := filepath.Base(filename)
+	opts := options{lint: podlint.DefaultOptions()}
+	flag.BoolVar(&opts.lint.RequireProtocol, "require-protocol", false, "require protocol to be set explicitly on every container port")
+	flag.Int64Var(&opts.lint.MaxFileSize, "max-file-size", opts.lint.MaxFileSize, "maximum input file size in bytes (0 disables the limit)")
+	flag.IntVar(&opts.lint.MaxDepth, "max-depth", opts.lint.MaxDepth, "maximum YAML nesting depth (0 disables the limit)")
+	flag.IntVar(&opts.lint.MaxDocuments, "max-documents", opts.lint.MaxDocuments, "maximum number of YAML documents per file (0 disables the limit)")
+	flag.BoolVar(&opts.lint.DenyLatest, "deny-latest", false, "reject images tagged latest")
+	flag.BoolVar(&opts.lint.RequireDigest, "require-digest", false, "require images to be pinned by @sha256 digest")
+	flag.StringVar(&opts.lint.Fragment, "fragment", "", "validate a fragment instead of a Pod: container or podspec")
+	flag.StringVar(&opts.lint.HASelector, "ha-selector", opts.lint.HASelector, "labels of pods that must define podAntiAffinity or topologySpreadConstraints (empty disables the check)")
+	flag.StringVar(&opts.k8sVersion, "k8s-version", "", "Kubernetes version or range the manifests must support, e.g. 1.27-1.29")
+	flag.StringVar(&opts.config, "config", "", "configuration file (default "+podlint.DefaultConfigFile+" in the current directory, if present)")
+	flag.StringVar(&opts.env, "env", "", "environment from the environments block of the configuration, e.g. prod")
+	flag.BoolVar(&opts.recursive, "recursive", false, "descend into subdirectories of directory arguments")
+	flag.IntVar(&opts.concurrency, "concurrency", runtime.NumCPU(), "number of files read and validated in parallel")
+	flag.StringVar(&opts.output, "output", "text", "report format: text, json, sarif, junit or kubeconform-json")
+	flag.StringVar(&opts.failOn, "fail-on", podlint.SeverityError, "lowest severity that makes the run fail: error, warning or none")
+	flag.StringVar(&opts.requireRules, "require-rules", "", "comma-separated rule IDs that must stay enabled, e.g. PORT002,POD002")
+	flag.BoolVar(&opts.column, "column", false, "include the column in text output (file:line:column)")
+	flag.StringVar(&opts.groupBy, "group-by", "file", "group text output by file or by rule (counts with a few samples per rule)")
+	flag.BoolVar(&opts.summaryOnly, "summary-only", false, "with --output json, emit only counts per rule, severity and file")
+	flag.BoolVar(&opts.showSuppressed, "show-suppressed", false, "also report findings suppressed by an earlier failure of a rule they depend on (text and json output)")
+	flag.BoolVar(&opts.verbose, "verbose", false, "report per-file details such as the detected input encoding to stderr")
+	flag.BoolVar(&opts.source, "show-source", false, "print the offending YAML line with a caret under each finding in text output")
+	flag.StringVar(&opts.notifyWebhook, "notify-webhook", "", "URL to POST a notification to when the run has errors (regardless of --fail-on)")
+	flag.StringVar(&opts.notifyFormat, "notify-format", "json", "notification payload: json (full report) or slack (text message)")
+	flag.DurationVar(&opts.networkTimeout, "network-timeout", 10*time.Second, "timeout of a single network request; failed requests are retried with backoff")
+	flag.BoolVar(&opts.offline, "offline", false, "make no network requests: URL inputs and --notify-webhook are rejected")
 
-	// --- metadata.name ---
-	if metadata, ok := raw["metadata"].(map[string]interface{}); ok {
-		if name, ok := metadata["name"].(string); !ok || name == "" {
-			fmt.Printf("%s:4 name is required\n", base)
+	// Флаги можно указывать и после путей: yamlvalid ./manifests/ --recursive
+	var args []string
+	rest := os.Args[1:]
+	for {
+		if err := flag.CommandLine.Parse(rest); err != nil {
+			os.Exit(2)
 		}
+		rest = flag.Args()
+		if len(rest) == 0 {
+			break
+		}
+		args = append(args, rest[0])
+		rest = rest[1:]
 	}
 
-	// --- spec ---
-	if spec, ok := raw["spec"].(map[string]interface{}); ok {
-		// --- spec.os ---
-		if osField, ok := spec["os"]; ok {
-			if osName, ok := osField.(string); ok {
-				if osName != "linux" && osName != "windows" {
-					fmt.Printf("%s:10 os has unsupported value '%s'\n", base, osName)
-				}
+	switch opts.lint.Fragment {
+	case "", podlint.FragmentContainer, podlint.FragmentPodSpec:
+	default:
+		fmt.Printf("unknown fragment kind '%s', expected container or podspec\n", opts.lint.Fragment)
+		os.Exit(2)
+	}
+	switch opts.output {
+	case "text", "json", "sarif", "junit", "kubeconform-json":
+	default:
+		fmt.Printf("unknown output format '%s', expected text, json, sarif, junit or kubeconform-json\n", opts.output)
+		os.Exit(2)
+	}
+	switch opts.failOn {
+	case podlint.SeverityError, podlint.SeverityWarning, "none":
+	case "never": // прежнее название "none"
+		opts.failOn = "none"
+	default:
+		fmt.Printf("unknown fail-on level '%s', expected error, warning or none\n", opts.failOn)
+		os.Exit(2)
+	}
+	if opts.groupBy != "file" && opts.groupBy != "rule" {
+		fmt.Printf("unknown grouping '%s', expected file or rule\n", opts.groupBy)
+		os.Exit(2)
+	}
+	if opts.summaryOnly && opts.output != "json" {
+		fmt.Println("--summary-only requires --output json")
+		os.Exit(2)
+	}
+	if opts.notifyFormat != "json" && opts.notifyFormat != "slack" {
+		fmt.Printf("unknown notification format '%s', expected json or slack\n", opts.notifyFormat)
+		os.Exit(2)
+	}
+	if opts.offline && opts.notifyWebhook != "" {
+		fmt.Println("--notify-webhook needs the network and cannot be used with --offline")
+		os.Exit(2)
+	}
+	if opts.concurrency < 1 {
+		fmt.Println("--concurrency must be at least 1")
+		os.Exit(2)
+	}
+	if opts.networkTimeout <= 0 {
+		fmt.Println("--network-timeout must be positive")
+		os.Exit(2)
+	}
+	if opts.k8sVersion != "" {
+		vr, err := podlint.ParseVersionRange(opts.k8sVersion)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+		opts.lint.KubernetesVersions = &vr
+	}
+	if len(args) < 1 {
+		fmt.Println("Usage: yamlvalid [flags] <file|dir|glob|-|url>...")
+		os.Exit(2)
+	}
+
+	if err := loadConfig(opts.config, opts.env, &opts.lint); err != nil {
+		fmt.Printf("config: %v\n", err)
+		os.Exit(2)
+	}
+
+	var required []string
+	if opts.requireRules != "" {
+		active := podlint.ActiveRules(opts.lint)
+		disabled := 0
+		for _, id := range strings.Split(opts.requireRules, ",") {
+			id = strings.TrimSpace(id)
+			required = append(required, id)
+			if _, known := active[id]; !known {
+				fmt.Printf("unknown rule '%s' in -require-rules\n", id)
+				os.Exit(2)
 			}
+			if !active[id] {
+				fmt.Printf("required rule %s is disabled by the current options or configuration\n", id)
+				disabled++
+			}
+		}
+		if disabled > 0 {
+			os.Exit(1)
 		}
+	}
+
+	// Источники аргументов: пути, "-" (stdin) и http(s):// URL
+	podlint.RegisterSource("", newFSSource)
+	podlint.RegisterSource(podlint.SchemeStdin, newStdinSource)
+	client := newNetworkClient(opts.networkTimeout)
+	urls := urlSources(client)
+	if opts.offline {
+		urls = offlineSources
+	}
+	podlint.RegisterSource("http", urls)
+	podlint.RegisterSource("https", urls)
+
+	files, err := collectInputs(args, opts.recursive)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
 
-		// --- spec.containers ---
-		if containers, ok := spec["containers"].([]interface{}); ok {
-			for _, c := range containers {
-				container, ok := c.(map[string]interface{})
-				if !ok {
-					continue
-				}
-
-				// --- container.name ---
-				if name, ok := container["name"].(string); !ok || name == "" {
-					fmt.Printf("%s:12 name is required\n", base)
-				}
-
-				// --- container.ports[].containerPort ---
-				if ports, ok := container["ports"].([]interface{}); ok {
-					for _, p := range ports {
-						if portObj, ok := p.(map[string]interface{}); ok {
-							if port, ok := portObj["containerPort"]; ok {
-								if !validatePort(port) {
-									fmt.Printf("%s:15 containerPort value out of range\n", base)
-								}
-							}
-						}
-					}
-				}
-
-				// --- readinessProbe.httpGet.port ---
-				if probe, ok := container["readinessProbe"].(map[string]interface{}); ok {
-					if httpGet, ok := probe["httpGet"].(map[string]interface{}); ok {
-						if port, ok := httpGet["port"]; ok {
-							if !validatePort(port) {
-								fmt.Printf("%s:20 port value out of range\n", base)
-							}
-						}
-					}
-				}
-
-				// --- livenessProbe.httpGet.port ---
-				if probe, ok := container["livenessProbe"].(map[string]interface{}); ok {
-					if httpGet, ok := probe["httpGet"].(map[string]interface{}); ok {
-						if port, ok := httpGet["port"]; ok {
-							if !validatePort(port) {
-								fmt.Printf("%s:24 port value out of range\n", base)
-							}
-						}
-					}
-				}
-
-				// --- resources ---
-				if resources, ok := container["resources"].(map[string]interface{}); ok {
-					// --- limits.cpu ---
-					if limits, ok := resources["limits"].(map[string]interface{}); ok {
-						if cpu, ok := limits["cpu"]; ok {
-							switch cpu.(type) {
-							case int, int64, float64:
-								// OK
-							default:
-								fmt.Printf("%s:27 cpu must be int\n", base)
-							}
-						}
-					}
-
-					// --- requests.cpu ---
-					if requests, ok := resources["requests"].(map[string]interface{}); ok {
-						if cpu, ok := requests["cpu"]; ok {
-							switch cpu.(type) {
-							case int, int64, float64:
-								// OK
-							default:
-								fmt.Printf("%s:30 cpu must be int\n", base)
-							}
-						}
-					}
-				}
+	kept := files[:0]
+	for _, file := range files {
+		if !opts.lint.IgnoredFile(file.name) {
+			kept = append(kept, file)
+		}
+	}
+	files = kept
+
+	results := validateFiles(files, opts)
+
+	// Единственный явно указанный файл (или stdin) выводится в тексте по
+	// базовому имени, как раньше; найденные в каталогах и по шаблонам файлы —
+	// по пути, чтобы одноимённые манифесты различались
+	single := singleInput(files, args)
+	switch opts.output {
+	case "json":
+		if opts.summaryOnly {
+			err = writeJSONSummary(os.Stdout, results, opts.failOn)
+			break
+		}
+		err = writeJSON(os.Stdout, results, opts.failOn)
+	case "sarif":
+		err = writeSARIF(os.Stdout, results)
+	case "junit":
+		err = writeJUnit(os.Stdout, results)
+	case "kubeconform-json":
+		err = writeKubeconformJSON(os.Stdout, results, opts.lint)
+	default:
+		if opts.groupBy == "rule" {
+			err = writeGrouped(os.Stdout, results, opts.failOn, opts.column)
+			break
+		}
+		err = writeText(os.Stdout, results, opts.failOn, !single, opts.column, opts.source)
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	// Правило, отключённое переопределениями для каждого проверенного
+	// документа, не выполнялось ни разу
+	uncovered := disabledEverywhere(results, required)
+	for _, id := range uncovered {
+		fmt.Fprintf(os.Stderr, "required rule %s is disabled for every checked document\n", id)
+	}
+
+	if opts.notifyWebhook != "" {
+		if err := notify(client, opts.notifyWebhook, opts.notifyFormat, results); err != nil {
+			fmt.Fprintf(os.Stderr, "notification failed: %v\n", err)
+		}
+	}
+	if shouldFail(results, opts.failOn) || len(uncovered) > 0 {
+		os.Exit(1)
+	}
+}
+
+// Правила из ids, отключённые во всех проверенных документах; если не
+// проверено ни одного документа, судить не о чем
+func disabledEverywhere(results []fileResult, ids []string) []string {
+	var uncovered []string
+	for _, id := range ids {
+		checked, enabled := false, false
+		for _, res := range results {
+			for _, disabled := range res.disabled {
+				checked = true
+				enabled = enabled || !disabled[id]
 			}
 		}
+		if checked && !enabled {
+			uncovered = append(uncovered, id)
+		}
 	}
+	return uncovered
 }
 
-func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: yamlvalid <filename>")
-		return
+// Есть ли нарушения уровня failOn или серьёзнее
+func shouldFail(results []fileResult, failOn string) bool {
+	for _, res := range results {
+		if res.failed(failOn) {
+			return true
+		}
 	}
-	filename := os.Args[1]
-	validateYAML(filename)
+	return false
 }
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"main.go/pkg/podlint"
+)
+
+const testPod = "apiVersion: v1\nkind: Pod\nmetadata:\n  name: web\nspec:\n  containers:\n    - name: web\n      image: web:1\n"
+
+// Запись файлов name → содержимое во временный каталог, возвращает его путь
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestDisabledEverywhere(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"jobs/job.yaml": testPod, "apps/pod.yaml": testPod})
+	lint := podlint.DefaultOptions()
+	lint.Overrides = []podlint.RuleOverride{{
+		Files:         []string{filepath.Join(dir, "jobs", "**")},
+		DisabledRules: map[string]bool{podlint.RuleProbeHandler: true},
+	}}
+	check := func(names ...string) []string {
+		var results []fileResult
+		for _, name := range names {
+			in := inputFile{name: filepath.Join(dir, name), source: fsSource{}}
+			results = append(results, validateFile(in, lint, false, false, false))
+		}
+		return disabledEverywhere(results, []string{podlint.RuleProbeHandler, podlint.RuleImageTag})
+	}
+
+	// Правило отключено только для jobs/**: файл вне jobs его проверяет
+	if got := check("apps/pod.yaml"); len(got) != 0 {
+		t.Errorf("apps/pod.yaml: unexpected uncovered rules %v", got)
+	}
+	if got := check("jobs/job.yaml", "apps/pod.yaml"); len(got) != 0 {
+		t.Errorf("both files: unexpected uncovered rules %v", got)
+	}
+	// Во всех проверенных документах правило отключено
+	if got := check("jobs/job.yaml"); len(got) != 1 || got[0] != podlint.RuleProbeHandler {
+		t.Errorf("jobs/job.yaml: uncovered rules %v, want [%s]", got, podlint.RuleProbeHandler)
+	}
+	// Без проверенных документов судить не о чем
+	if got := disabledEverywhere(nil, []string{podlint.RuleProbeHandler}); len(got) != 0 {
+		t.Errorf("no results: unexpected uncovered rules %v", got)
+	}
+}
+
+// Источник, в котором первые файлы открываются дольше последних
+type slowSource struct {
+	podlint.MemorySource
+	delay map[string]time.Duration
+}
+
+func (s slowSource) Open(name string) (io.ReadCloser, error) {
+	time.Sleep(s.delay[name])
+	return s.MemorySource.Open(name)
+}
+
+// Результаты идут в порядке входных файлов при любом числе потоков
+func TestValidateFilesOrder(t *testing.T) {
+	src := slowSource{podlint.MemorySource{}, make(map[string]time.Duration)}
+	var files []inputFile
+	const n = 20
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("pod-%02d.yaml", i)
+		data := testPod
+		if i%3 == 0 {
+			data = strings.Replace(testPod, "web:1", "nginx", 1)
+		}
+		src.MemorySource[name] = []byte(data)
+		src.delay[name] = time.Duration(n-i) * time.Millisecond
+		files = append(files, inputFile{name, src})
+	}
+
+	for _, concurrency := range []int{1, 4, n * 2} {
+		opts := options{lint: podlint.DefaultOptions(), concurrency: concurrency}
+		results := validateFiles(files, opts)
+		if len(results) != n {
+			t.Fatalf("concurrency %d: %d results, want %d", concurrency, len(results), n)
+		}
+		for i, res := range results {
+			if res.file != files[i].name {
+				t.Errorf("concurrency %d: result %d is %s, want %s", concurrency, i, res.file, files[i].name)
+			}
+			if failed := len(res.findings) > 0; failed != (i%3 == 0) {
+				t.Errorf("concurrency %d: %s has findings %v", concurrency, res.file, res.findings)
+			}
+		}
+
+		// Отчёт выводит нарушения в том же порядке
+		var out strings.Builder
+		if err := writeText(&out, results, podlint.SeverityError, true, false, false); err != nil {
+			t.Fatal(err)
+		}
+		last := -1
+		for i := 0; i < n; i += 3 {
+			pos := strings.Index(out.String(), fmt.Sprintf("pod-%02d.yaml:", i))
+			if pos <= last {
+				t.Errorf("concurrency %d: findings of pod-%02d.yaml are out of order:\n%s", concurrency, i, out.String())
+				break
+			}
+			last = pos
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"main.go/pkg/podlint"
+)
+
+// Подкоманда migrate: перевод флагов kubeconform/kubeval из файла в
+// конфигурацию yamlvalid. Конфигурация печатается в stdout, параметры
+// без аналога перечисляются в комментариях в её начале.
+func runMigrate(args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	from := fs.String("from", "kubeconform", "source tool: kubeconform or kubeval")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *from != "kubeconform" && *from != "kubeval" {
+		fmt.Printf("unknown source tool '%s', expected kubeconform or kubeval\n", *from)
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fmt.Println("Usage: yamlvalid migrate [--from kubeconform|kubeval] <flags-file>")
+		return 2
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		fmt.Println(err)
+		return 2
+	}
+
+	cfg, dropped := migrateFlags(*from, splitArgs(string(data)))
+
+	fmt.Printf("# Migrated from %s flags in %s\n", *from, fs.Arg(0))
+	for _, d := range dropped {
+		fmt.Printf("# not supported, dropped: %s\n", d)
+	}
+	enc := yaml.NewEncoder(os.Stdout)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		fmt.Println(err)
+		return 2
+	}
+	return 0
+}
+
+// Перевод флагов в конфигурацию; возвращает и флаги, у которых нет аналога
+func migrateFlags(from string, args []string) (*podlint.Config, []string) {
+	cfg := &podlint.Config{}
+	var dropped []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			dropped = append(dropped, arg)
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+
+		// Флаги без значения
+		switch name {
+		case "strict", "ignore-missing-schemas", "summary", "verbose", "v", "exit-on-error", "insecure-skip-tls-verify", "quiet", "debug", "force-color", "openshift":
+			if name != "ignore-missing-schemas" {
+				dropped = append(dropped, arg)
+			}
+			continue
+		}
+
+		if !hasValue && i+1 < len(args) {
+			i++
+			value = args[i]
+			arg += " " + value
+		}
+
+		switch {
+		case from == "kubeconform" && name == "skip", from == "kubeval" && name == "skip-kinds":
+			for _, kind := range strings.Split(value, ",") {
+				if kind = strings.TrimSpace(kind); kind != "" {
+					cfg.SkipKinds = append(cfg.SkipKinds, kind)
+				}
+			}
+		case from == "kubeconform" && name == "ignore-filename-pattern":
+			cfg.IgnoreFilenamePatterns = append(cfg.IgnoreFilenamePatterns, value)
+		case from == "kubeval" && name == "ignored-filename-patterns":
+			for _, pattern := range strings.Split(value, ",") {
+				if pattern = strings.TrimSpace(pattern); pattern != "" {
+					cfg.IgnoreFilenamePatterns = append(cfg.IgnoreFilenamePatterns, pattern)
+				}
+			}
+		default:
+			// schema-location, kubernetes-version, output, reject и прочее
+			dropped = append(dropped, arg)
+		}
+	}
+	return cfg, dropped
+}
+
+// Разбиение текста на аргументы как в shell: пробелы, кавычки, строки-комментарии
+func splitArgs(text string) []string {
+	var args []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		var cur strings.Builder
+		inArg := false
+		var quote rune
+		for _, c := range strings.TrimSuffix(line, "\\") {
+			switch {
+			case quote != 0 && c == quote:
+				quote = 0
+			case quote != 0:
+				cur.WriteRune(c)
+			case c == '\'' || c == '"':
+				quote, inArg = c, true
+			case unicode.IsSpace(c):
+				if inArg {
+					args = append(args, cur.String())
+					cur.Reset()
+					inArg = false
+				}
+			default:
+				cur.WriteRune(c)
+				inArg = true
+			}
+		}
+		if inArg {
+			args = append(args, cur.String())
+		}
+	}
+	return args
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"main.go/pkg/podlint"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"-strict -skip Secret", []string{"-strict", "-skip", "Secret"}},
+		{`-skip "Secret,Config Map" -ignore-filename-pattern '.*\.tpl'`, []string{"-skip", "Secret,Config Map", "-ignore-filename-pattern", `.*\.tpl`}},
+		{`-output=json --summary ""`, []string{"-output=json", "--summary", ""}},
+		{"# comment\n  # indented comment\n-strict \\\n  -summary\n", []string{"-strict", "-summary"}},
+		{"-a'b c'd", []string{"-ab cd"}},
+		{`-skip "it's"`, []string{"-skip", "it's"}},
+		{"\t\n  ", nil},
+	}
+	for _, tt := range tests {
+		if got := splitArgs(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitArgs(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMigrateFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		flags    string
+		kinds    []string
+		patterns []string
+		dropped  []string
+	}{
+		{
+			name:     "kubeconform",
+			from:     "kubeconform",
+			flags:    `-strict -summary -skip "Secret, CustomResourceDefinition" -ignore-filename-pattern '.*\.tpl' --ignore-filename-pattern=^charts/`,
+			kinds:    []string{"Secret", "CustomResourceDefinition"},
+			patterns: []string{`.*\.tpl`, "^charts/"},
+			dropped:  []string{"-strict", "-summary"},
+		},
+		{
+			name:     "kubeval",
+			from:     "kubeval",
+			flags:    "--skip-kinds=Secret,Job --ignored-filename-patterns 'a.yaml, b.yaml' --ignore-missing-schemas",
+			kinds:    []string{"Secret", "Job"},
+			patterns: []string{"a.yaml", "b.yaml"},
+		},
+		{
+			name:    "unknown flags and values",
+			from:    "kubeconform",
+			flags:   "-schema-location default -kubernetes-version=1.29.0 -output json -reject v1/Secret manifests/",
+			dropped: []string{"-schema-location default", "-kubernetes-version=1.29.0", "-output json", "-reject v1/Secret", "manifests/"},
+		},
+		{
+			name:    "flags of the other tool",
+			from:    "kubeval",
+			flags:   "-skip Secret -ignore-filename-pattern x",
+			dropped: []string{"-skip Secret", "-ignore-filename-pattern x"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, dropped := migrateFlags(tt.from, splitArgs(tt.flags))
+			if !reflect.DeepEqual(cfg.SkipKinds, tt.kinds) || !reflect.DeepEqual(cfg.IgnoreFilenamePatterns, tt.patterns) ||
+				!reflect.DeepEqual(dropped, tt.dropped) {
+				t.Errorf("skipKinds %q, patterns %q, dropped %q; want %q, %q, %q",
+					cfg.SkipKinds, cfg.IgnoreFilenamePatterns, dropped, tt.kinds, tt.patterns, tt.dropped)
+			}
+
+			// Напечатанная конфигурация загружается обратно с тем же смыслом
+			data, err := yaml.Marshal(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(t.TempDir(), podlint.DefaultConfigFile)
+			if err := os.WriteFile(path, data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			loaded, err := podlint.LoadConfig(path)
+			if err != nil {
+				t.Fatalf("%v\n%s", err, data)
+			}
+			opts := podlint.DefaultOptions()
+			if err := loaded.Apply(&opts); err != nil {
+				t.Fatal(err)
+			}
+			for _, kind := range tt.kinds {
+				if !opts.SkipsKind(kind) {
+					t.Errorf("kind %s is not skipped after loading:\n%s", kind, data)
+				}
+			}
+			if len(opts.IgnoreFilenames) != len(tt.patterns) {
+				t.Errorf("%d ignore patterns after loading, want %d:\n%s", len(opts.IgnoreFilenames), len(tt.patterns), data)
+			}
+			for i, re := range opts.IgnoreFilenames {
+				if re.String() != tt.patterns[i] {
+					t.Errorf("ignore pattern %d = %q, want %q", i, re, tt.patterns[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"main.go/pkg/podlint"
+)
+
+// Повторы сетевых запросов: число попыток и задержка перед первым повтором,
+// которая удваивается с каждой попыткой
+const (
+	networkAttempts = 3
+	networkBackoff  = 500 * time.Millisecond
+)
+
+// Общий HTTP-клиент всех сетевых функций. Соединения переиспользуются,
+// прокси берётся из HTTP_PROXY, HTTPS_PROXY и NO_PROXY.
+type networkClient struct {
+	http    *http.Client
+	backoff time.Duration
+}
+
+// Клиент с ограничением времени одного запроса timeout
+func newNetworkClient(timeout time.Duration) *networkClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	return &networkClient{
+		http:    &http.Client{Timeout: timeout, Transport: transport},
+		backoff: networkBackoff,
+	}
+}
+
+// POST с телом body. Запрос не идемпотентен: после ответа 5xx или тайм-аута
+// получатель мог его уже обработать, поэтому он повторяется, только если не
+// был отправлен (ошибка соединения) или отклонён ответом 429.
+func (c *networkClient) post(url, contentType string, body []byte) (*http.Response, error) {
+	return c.do(false, func() (*http.Response, error) {
+		return c.http.Post(url, contentType, bytes.NewReader(body))
+	})
+}
+
+// GET, повторы как у do
+func (c *networkClient) get(url string) (*http.Response, error) {
+	return c.do(true, func() (*http.Response, error) {
+		return c.http.Get(url)
+	})
+}
+
+// Запрос send с повторами: сетевые ошибки, 429 и ответы 5xx повторяются с
+// экспоненциальной задержкой, остальные ответы возвращаются сразу. Запрос,
+// который не idempotent, повторяется только в случаях из unsent и при 429.
+func (c *networkClient) do(idempotent bool, send func() (*http.Response, error)) (*http.Response, error) {
+	delay := c.backoff
+	for attempt := 1; ; attempt++ {
+		resp, err := send()
+		if err != nil && !idempotent && !unsent(err) {
+			return nil, err
+		}
+		if err == nil && !retryable(resp.StatusCode, idempotent) {
+			return resp, nil
+		}
+		if attempt == networkAttempts {
+			if err != nil {
+				return nil, fmt.Errorf("%w (after %d attempts)", err, attempt)
+			}
+			return resp, nil
+		}
+		if err == nil {
+			resp.Body.Close()
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// Стоит ли повторить запрос с таким кодом ответа
+func retryable(status int, idempotent bool) bool {
+	return status == http.StatusTooManyRequests || idempotent && status >= 500
+}
+
+// Не дошёл ли запрос до сервера: соединение не установлено
+func unsent(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// Источник из одного файла по HTTP(S) URL
+type urlSource struct {
+	url    string
+	client *networkClient
+}
+
+// Фабрика источников для схем http и https, запросы идут через client
+func urlSources(client *networkClient) podlint.SourceFactory {
+	return func(arg string, recursive bool) (podlint.Source, error) {
+		return urlSource{arg, client}, nil
+	}
+}
+
+// Фабрика источников для схем http и https при --offline: URL не открываются
+func offlineSources(arg string, recursive bool) (podlint.Source, error) {
+	return nil, fmt.Errorf("%s: URL inputs need the network and are not allowed with --offline", arg)
+}
+
+func (s urlSource) Files() ([]string, error) {
+	return []string{s.url}, nil
+}
+
+func (s urlSource) Open(name string) (io.ReadCloser, error) {
+	resp, err := s.client.get(name)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s", name, resp.Status)
+	}
+	return resp.Body, nil
+}
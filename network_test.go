@@ -0,0 +1,112 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"main.go/pkg/podlint"
+)
+
+// Сервер, отвечающий по очереди кодами statuses (последний — на все
+// остальные запросы), и счётчик запросов
+func statusServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1))
+		w.WriteHeader(statuses[min(n, len(statuses))-1])
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func testClient() *networkClient {
+	return &networkClient{http: &http.Client{Timeout: time.Second}, backoff: time.Millisecond}
+}
+
+func TestNetworkRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		post     bool
+		statuses []int
+		want     int // код последнего ответа
+		requests int32
+	}{
+		{"get retries 5xx", false, []int{500, 502, 200}, 200, 3},
+		{"get gives up", false, []int{503}, 503, networkAttempts},
+		{"get does not retry 404", false, []int{404}, 404, 1},
+		{"post does not retry 5xx", true, []int{500, 200}, 500, 1},
+		{"post retries 429", true, []int{429, 200}, 200, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := statusServer(t, tt.statuses...)
+			c := testClient()
+			var resp *http.Response
+			var err error
+			if tt.post {
+				resp, err = c.post(srv.URL, "application/json", []byte("{}"))
+			} else {
+				resp, err = c.get(srv.URL)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want || requests.Load() != tt.requests {
+				t.Errorf("status %d after %d requests, want %d after %d", resp.StatusCode, requests.Load(), tt.want, tt.requests)
+			}
+		})
+	}
+}
+
+func TestPostTimeoutNotRetried(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer srv.Close()
+	c := testClient()
+	c.http.Timeout = 50 * time.Millisecond
+	if _, err := c.post(srv.URL, "application/json", []byte("{}")); err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("timed out POST sent %d times, want 1", n)
+	}
+}
+
+func TestPostRetriedWhenUnsent(t *testing.T) {
+	// Адрес, на котором никто не слушает
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := "http://" + l.Addr().String()
+	l.Close()
+
+	_, err = testClient().post(url, "application/json", []byte("{}"))
+	if err == nil || !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Errorf("got %v, want a connection error after %d attempts", err, networkAttempts)
+	}
+}
+
+// При --offline URL-аргументы отклоняются без обращения к серверу
+func TestOfflineSources(t *testing.T) {
+	srv, requests := statusServer(t, 200)
+	podlint.RegisterSource("http", offlineSources)
+	t.Cleanup(func() { podlint.RegisterSource("http", urlSources(testClient())) })
+
+	_, err := collectInputs([]string{srv.URL + "/pod.yaml"}, false)
+	if err == nil || !strings.Contains(err.Error(), "not allowed with --offline") {
+		t.Errorf("got %v, want an --offline error", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("server got %d requests, want 0", n)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"main.go/pkg/podlint"
+)
+
+// Сколько нарушений перечислять в текстовом уведомлении
+const notifyMaxFindings = 20
+
+// Отправка уведомления на webhook, если в results есть нарушения уровня
+// error; от --fail-on это не зависит. Формат json отправляет полный отчёт,
+// slack — сообщение {"text": ...}.
+func notify(client *networkClient, url, format string, results []fileResult) error {
+	if !shouldFail(results, podlint.SeverityError) {
+		return nil
+	}
+	report := newJSONReport(results, podlint.SeverityError)
+
+	var payload interface{} = report
+	if format == "slack" {
+		var b strings.Builder
+		fmt.Fprintf(&b, "yamlvalid: %d of %d files failed validation (%d errors)",
+			report.Summary.Failed, report.Summary.Files, report.Summary.Errors)
+		for i, f := range report.Findings {
+			if i == notifyMaxFindings {
+				fmt.Fprintf(&b, "\n… and %d more", len(report.Findings)-i)
+				break
+			}
+			fmt.Fprintf(&b, "\n• %s:%d:%d [%s] %s", f.File, f.Line, f.Column, f.RuleID, f.Message)
+		}
+		payload = map[string]string{"text": b.String()}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.post(url, "application/json", body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
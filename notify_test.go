@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"main.go/pkg/podlint"
+)
+
+// Уведомление отправляется только при нарушениях уровня error
+func TestNotify(t *testing.T) {
+	warning := podlint.ValidationError{File: "a.yaml", RuleID: podlint.RuleImageLatest, Message: "image tag 'latest' is not allowed", Severity: podlint.SeverityWarning}
+	failure := podlint.ValidationError{File: "b.yaml", RuleID: podlint.RuleImageTag, Message: "image has invalid format 'nginx'", Severity: podlint.SeverityError}
+	tests := []struct {
+		name     string
+		findings []podlint.ValidationError
+		sent     bool
+	}{
+		{"no findings", nil, false},
+		{"warnings only", []podlint.ValidationError{warning}, false},
+		{"errors", []podlint.ValidationError{warning, failure}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ = io.ReadAll(r.Body)
+			}))
+			defer srv.Close()
+
+			results := []fileResult{{file: "a.yaml", findings: tt.findings}}
+			if err := notify(testClient(), srv.URL, "json", results); err != nil {
+				t.Fatal(err)
+			}
+			if (body != nil) != tt.sent {
+				t.Fatalf("notification sent = %v, want %v", body != nil, tt.sent)
+			}
+			if !tt.sent {
+				return
+			}
+			var report jsonReport
+			if err := json.Unmarshal(body, &report); err != nil {
+				t.Fatal(err)
+			}
+			if report.Valid || report.Summary.Errors != 1 || report.Summary.Warnings != 1 {
+				t.Errorf("payload: valid %v, summary %+v", report.Valid, report.Summary)
+			}
+		})
+	}
+}
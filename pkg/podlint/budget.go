@@ -0,0 +1,50 @@
+package podlint
+
+import (
+	"fmt"
+
+	"main.go/pkg/quantity"
+)
+
+// Проверка бюджетов PodSpec: число контейнеров и суммарные cpu и memory
+func validateBudget(r *reporter, spec map[string]interface{}, path []interface{}, opts Options) {
+	containers, _ := spec["containers"].([]interface{})
+
+	// --- число контейнеров ---
+	if opts.MaxContainers > 0 && len(containers) > opts.MaxContainers {
+		r.errorf(RuleMaxContainers, subPath(path, "containers"), "pod has %d containers, budget is %d", len(containers), opts.MaxContainers)
+	}
+
+	// --- суммарные ресурсы ---
+	budgets := map[string]quantity.Quantity{"cpu": opts.MaxPodCPU, "memory": opts.MaxPodMemory}
+	for _, resource := range []string{"cpu", "memory"} {
+		budget := budgets[resource]
+		if budget.Sign() <= 0 {
+			continue
+		}
+		var total quantity.Quantity
+		for _, c := range containers {
+			container, _ := c.(map[string]interface{})
+			if q, ok := containerResource(container, resource); ok {
+				total = total.Add(q)
+			}
+		}
+		if total.Cmp(budget) > 0 {
+			r.errorf(RulePodBudget, subPath(path, "containers"), "pod %s %s exceeds budget of %s", resource, total, budget)
+		}
+	}
+}
+
+// Ресурс контейнера из limits, а без них — из requests. Значения, которые
+// не удалось разобрать, не учитываются.
+func containerResource(container map[string]interface{}, resource string) (quantity.Quantity, bool) {
+	resources, _ := container["resources"].(map[string]interface{})
+	for _, section := range []string{"limits", "requests"} {
+		values, _ := resources[section].(map[string]interface{})
+		if value, ok := values[resource]; ok {
+			q, err := quantity.Parse(fmt.Sprint(value))
+			return q, err == nil
+		}
+	}
+	return quantity.Quantity{}, false
+}
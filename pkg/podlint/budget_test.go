@@ -0,0 +1,36 @@
+package podlint
+
+import (
+	"testing"
+
+	"main.go/pkg/quantity"
+)
+
+var budgetRuleCases = []ruleCase{
+	{rule: RuleMaxContainers, yaml: validPod,
+		opts: func(o *Options) { o.MaxContainers = 1 }},
+	{rule: RuleMaxContainers, fail: true, yaml: pod("\n  containers:\n    - name: a\n      image: a:1\n    - name: b\n      image: b:1"),
+		opts: func(o *Options) { o.MaxContainers = 1 }},
+
+	{rule: RulePodBudget, yaml: validPod,
+		opts: func(o *Options) { o.MaxPodCPU = mustQuantity("1") }},
+	{rule: RulePodBudget, fail: true, yaml: validPod,
+		opts: func(o *Options) { o.MaxPodMemory = mustQuantity("100Mi") }},
+
+	{rule: RuleManifestSize, yaml: validPod,
+		opts: func(o *Options) { o.MaxManifestSize = 1 << 10 }},
+	{rule: RuleManifestSize, fail: true, yaml: validPod,
+		opts: func(o *Options) { o.MaxManifestSize = 100 }},
+}
+
+func TestBudgetRules(t *testing.T) {
+	testRuleCases(t, budgetRuleCases)
+}
+
+func mustQuantity(s string) quantity.Quantity {
+	q, err := quantity.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
@@ -0,0 +1,508 @@
+package podlint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"main.go/pkg/quantity"
+)
+
+// Сбор нарушений одного документа
+type reporter struct {
+	document   int
+	root       *yaml.Node        // проверяемый документ, по нему определяются позиции
+	docs       []*Document       // все документы файла
+	disabled   map[string]bool   // отключённые правила
+	severities map[string]string // уровни серьёзности, переопределённые конфигурацией
+	findings   []ValidationError
+	suppressed []ValidationError // нарушения, подавленные первопричиной, см. ruleDependencies
+}
+
+// Сборщик нарушений документа doc из файла file с учётом Options.Packs и
+// Options.Overrides
+func newReporter(file string, doc *Document, docs []*Document, opts Options) *reporter {
+	disabled, severities := documentRules(file, doc, docs, opts)
+	return &reporter{document: doc.Index, root: doc.Node, docs: docs, disabled: disabled, severities: severities}
+}
+
+// Отключённые правила и уровни серьёзности для документа doc из файла file:
+// DisabledRules и Severities, поверх них подходящие Packs, затем Overrides
+func documentRules(file string, doc *Document, docs []*Document, opts Options) (disabled map[string]bool, severities map[string]string) {
+	disabled, severities = opts.DisabledRules, opts.Severities
+	if len(opts.Packs) > 0 {
+		labels := namespaceLabels(doc, docs, opts)
+		for _, p := range opts.Packs {
+			if p.matches(labels) {
+				disabled = mergeRules(disabled, p.DisabledRules)
+				severities = mergeRules(severities, p.Severities)
+			}
+		}
+	}
+	for _, o := range opts.Overrides {
+		if !o.matches(file, doc) {
+			continue
+		}
+		disabled = mergeRules(disabled, o.DisabledRules)
+		severities = mergeRules(severities, o.Severities)
+	}
+	return disabled, severities
+}
+
+// Копия base с заменёнными значениями из override
+func mergeRules[T any](base, override map[string]T) map[string]T {
+	if len(override) == 0 {
+		return base
+	}
+	merged := make(map[string]T, len(base)+len(override))
+	for id, v := range base {
+		merged[id] = v
+	}
+	for id, v := range override {
+		merged[id] = v
+	}
+	return merged
+}
+
+// Нарушение правила rule в поле path (путь в дереве узлов)
+func (r *reporter) errorf(rule string, path []interface{}, format string, args ...interface{}) {
+	r.relatedf(rule, path, nil, format, args...)
+}
+
+// Нарушение правила rule в имени ключа path: позиция указывает на ключ,
+// а не на его значение
+func (r *reporter) keyErrorf(rule string, path []interface{}, format string, args ...interface{}) {
+	n := len(r.findings)
+	r.relatedf(rule, path, nil, format, args...)
+	if len(r.findings) > n {
+		r.findings[n].Line, r.findings[n].Column = keyPos(r.root, path)
+	}
+}
+
+// Связанное с нарушением место: поле path и пояснение message
+func (r *reporter) location(path []interface{}, message string) Location {
+	line, column := nodePos(r.root, path)
+	return Location{Line: line, Column: column, FieldPath: fieldPath(path), Message: message}
+}
+
+// Нарушение, как у errorf, со связанными местами related
+func (r *reporter) relatedf(rule string, path []interface{}, related []Location, format string, args ...interface{}) {
+	if r.disabled[rule] {
+		return
+	}
+	severity := SeverityError
+	if ri, ok := LookupRule(rule); ok {
+		severity = ri.Severity
+	}
+	if s, ok := r.severities[rule]; ok {
+		severity = s
+	}
+	line, column := nodePos(r.root, path)
+	r.findings = append(r.findings, ValidationError{
+		Document:  r.document,
+		Line:      line,
+		Column:    column,
+		FieldPath: fieldPath(path),
+		RuleID:    rule,
+		Message:   fmt.Sprintf(format, args...),
+		Severity:  severity,
+		Related:   related,
+		path:      path,
+	})
+}
+
+// Ключи объекта в порядке сортировки, чтобы нарушения выводились стабильно
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Проверка диапазона порта
+func validatePort(value interface{}) bool {
+	switch v := value.(type) {
+	case int:
+		return v > 0 && v < 65536
+	case int64:
+		return v > 0 && v < 65536
+	case float64:
+		return int(v) > 0 && int(v) < 65536
+	default:
+		return false
+	}
+}
+
+// Допустим ли ключ ресурса; расширенные ресурсы с доменом разрешены всегда
+func allowedResourceKey(key string, allowed []string) bool {
+	if strings.Contains(key, "/") {
+		return true
+	}
+	for _, a := range allowed {
+		if key == a {
+			return true
+		}
+	}
+	return false
+}
+
+// Проверка образа: разрешённый реестр и наличие тега
+func validateImage(r *reporter, image string, path []interface{}, opts Options) {
+	if len(opts.Registries) > 0 {
+		allowed := false
+		for _, prefix := range opts.Registries {
+			if strings.HasPrefix(image, prefix) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			r.errorf(RuleImageRegistry, path, "image has unsupported registry '%s'", image)
+		}
+	}
+
+	// Тег — часть после ":" в последнем сегменте пути (двоеточие раньше — порт реестра),
+	// digest — часть после "@"
+	ref, digest, pinned := strings.Cut(image, "@")
+	name := ref[strings.LastIndex(ref, "/")+1:]
+	_, tag, tagged := strings.Cut(name, ":")
+	if (!tagged || tag == "") && !pinned {
+		r.errorf(RuleImageTag, path, "image has invalid format '%s'", image)
+	}
+
+	// --- политика тегов ---
+	if tagged && tag != "" {
+		switch {
+		case opts.DenyLatest && tag == "latest":
+			r.errorf(RuleImageLatest, path, "image tag 'latest' is not allowed")
+		case opts.SemverTags && !semverTag.MatchString(tag):
+			r.errorf(RuleImageSemver, path, "image tag '%s' is not a semantic version", tag)
+		}
+	}
+	if opts.RequireDigest && !strings.HasPrefix(digest, "sha256:") {
+		r.errorf(RuleImageDigest, path, "image '%s' must be pinned by @sha256 digest", image)
+	}
+
+	// --- тег для окружения ---
+	if tagged && opts.ImageTag != nil && !opts.ImageTag.MatchString(tag) {
+		r.errorf(RuleImageEnvTag, path, "image tag '%s' is not allowed in this environment", tag)
+	}
+}
+
+// Тег вида семантической версии: 1.2, v1.2.3, 1.2.3-rc.1
+var semverTag = regexp.MustCompile(`^v?\d+\.\d+(\.\d+)?([-+][0-9A-Za-z.-]+)?$`)
+
+// Проверка, что метки удовлетворяют селектору вида "key=value,key2=value2"
+func matchSelector(selector string, labels map[string]interface{}) bool {
+	for _, term := range strings.Split(selector, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(term), "=")
+		if v, ok := labels[key].(string); !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Проверка документа по его kind: Pod, контроллер, Service, ConfigMap или Secret; у остальных
+// kind проверяются только метаданные (или фрагмент, если задан opts.Fragment)
+func validatePod(r *reporter, raw map[string]interface{}, opts Options) {
+	switch opts.Fragment {
+	case FragmentContainer:
+		validateContainer(r, raw, nil, opts)
+		return
+	case FragmentPodSpec:
+		validateSpec(r, raw, nil, opts)
+		validateBudget(r, raw, nil, opts)
+		return
+	}
+
+	// --- обязательные поля из конфигурации ---
+	for _, field := range opts.RequiredFields {
+		var path []interface{}
+		var value interface{} = raw
+		for _, key := range strings.Split(field, ".") {
+			path = append(path, key)
+			if m, ok := value.(map[string]interface{}); ok {
+				value = m[key]
+			} else {
+				value = nil
+			}
+		}
+		if value == nil {
+			r.errorf(RuleFieldRequired, path, "%s is required", path[len(path)-1])
+		}
+	}
+
+	// --- metadata.name ---
+	metadata, _ := raw["metadata"].(map[string]interface{})
+	if metadata != nil {
+		if name, ok := metadata["name"].(string); !ok || name == "" {
+			r.errorf(RuleNameRequired, []interface{}{"metadata", "name"}, "name is required")
+		}
+		validateMetadata(r, metadata, []interface{}{"metadata"}, opts)
+	}
+
+	kind, _ := raw["kind"].(string)
+	if kind == "ConfigMap" || kind == "Secret" {
+		validateConfigData(r, kind, raw)
+		return
+	}
+
+	spec, _ := raw["spec"].(map[string]interface{})
+	if spec == nil {
+		return
+	}
+	switch {
+	case kind == "" || kind == "Pod":
+		validatePodSpec(r, metadata, spec, []interface{}{"spec"}, opts)
+	case workloadKinds[kind]:
+		validateWorkload(r, kind, spec, opts)
+	case kind == "Service":
+		validateService(r, spec)
+	case kind == "NetworkPolicy":
+		validateNetworkPolicy(r, spec)
+	}
+}
+
+// Проверка PodSpec пода или шаблона с его метаданными: контейнеры и размещение
+func validatePodSpec(r *reporter, metadata, spec map[string]interface{}, path []interface{}, opts Options) {
+	validateSpec(r, spec, path, opts)
+	validateBudget(r, spec, path, opts)
+
+	// --- spec.affinity.podAntiAffinity / spec.topologySpreadConstraints ---
+	labels, _ := metadata["labels"].(map[string]interface{})
+	if opts.HASelector != "" && matchSelector(opts.HASelector, labels) {
+		affinity, _ := spec["affinity"].(map[string]interface{})
+		antiAffinity, _ := affinity["podAntiAffinity"].(map[string]interface{})
+		constraints, _ := spec["topologySpreadConstraints"].([]interface{})
+		if len(antiAffinity) == 0 && len(constraints) == 0 {
+			r.errorf(RuleHAPlacement, path, "podAntiAffinity or topologySpreadConstraints is required for %s", opts.HASelector)
+		}
+	}
+}
+
+// Проверка PodSpec, path — путь к нему в дереве узлов
+func validateSpec(r *reporter, spec map[string]interface{}, path []interface{}, opts Options) {
+	// --- spec.os ---
+	if osField, ok := spec["os"]; ok {
+		if osName, ok := osField.(string); ok {
+			if osName != "linux" && osName != "windows" {
+				r.errorf(RuleOSUnsupported, subPath(path, "os"), "os has unsupported value '%s'", osName)
+			}
+		}
+	}
+
+	// --- spec.volumes ---
+	volumes := validateVolumes(r, spec, path)
+
+	// --- securityContext, hostPath, hostNetwork ---
+	validateSecurity(r, spec, path)
+
+	// --- повторы имён контейнеров и портов в поде ---
+	validateDuplicates(r, spec, path)
+
+	// --- spec.containers ---
+	containers, _ := spec["containers"].([]interface{})
+	for i, c := range containers {
+		if container, ok := c.(map[string]interface{}); ok {
+			validateContainer(r, container, subPath(path, "containers", i), opts)
+			validateMounts(r, container, subPath(path, "containers", i), volumes)
+		}
+	}
+
+	// --- spec.initContainers ---
+	initContainers, _ := spec["initContainers"].([]interface{})
+	for i, c := range initContainers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		validateContainer(r, container, subPath(path, "initContainers", i), opts)
+		validateMounts(r, container, subPath(path, "initContainers", i), volumes)
+
+		// Пробы разрешены только sidecar-контейнерам с restartPolicy: Always
+		if container["restartPolicy"] != "Always" {
+			for _, probe := range []string{"livenessProbe", "readinessProbe", "startupProbe"} {
+				if _, ok := container[probe]; ok {
+					r.errorf(RuleInitProbe, subPath(path, "initContainers", i, probe), "%s is ignored for init containers", probe)
+				}
+			}
+		}
+		for _, m := range writableMounts(container) {
+			for _, c := range containers {
+				other, _ := c.(map[string]interface{})
+				if sharesMount(writableMounts(other), m) {
+					r.errorf(RuleInitSharedRW, subPath(path, "initContainers", i, "volumeMounts", m.index),
+						"mountPath '%s' of volume '%s' is writable in init container and container '%v'", m.path, m.volume, other["name"])
+				}
+			}
+		}
+	}
+
+	// --- spec.ephemeralContainers ---
+	ephemeralContainers, _ := spec["ephemeralContainers"].([]interface{})
+	for i, c := range ephemeralContainers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		validateContainer(r, container, subPath(path, "ephemeralContainers", i), opts)
+		validateMounts(r, container, subPath(path, "ephemeralContainers", i), volumes)
+		for _, field := range []string{"ports", "livenessProbe", "readinessProbe", "startupProbe", "resources", "lifecycle"} {
+			if _, ok := container[field]; ok {
+				r.errorf(RuleEphemeral, subPath(path, "ephemeralContainers", i, field), "%s is not allowed for ephemeral containers", field)
+			}
+		}
+	}
+}
+
+// Монтирование тома: имя тома, путь, подкаталог и индекс в volumeMounts
+type volumeMount struct {
+	volume, path, subPath string
+	index                 int
+}
+
+// Монтирования контейнера без readOnly: true
+func writableMounts(container map[string]interface{}) []volumeMount {
+	var mounts []volumeMount
+	list, _ := container["volumeMounts"].([]interface{})
+	for i, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if readOnly, _ := m["readOnly"].(bool); readOnly {
+			continue
+		}
+		name, _ := m["name"].(string)
+		mountPath, _ := m["mountPath"].(string)
+		sub, _ := m["subPath"].(string)
+		mounts = append(mounts, volumeMount{name, mountPath, sub, i})
+	}
+	return mounts
+}
+
+// Есть ли среди mounts монтирование того же тома по тому же пути
+func sharesMount(mounts []volumeMount, m volumeMount) bool {
+	for _, other := range mounts {
+		if other.volume == m.volume && other.path == m.path && other.subPath == m.subPath {
+			return true
+		}
+	}
+	return false
+}
+
+// Проверка одного контейнера, path — путь к нему в дереве узлов
+func validateContainer(r *reporter, container map[string]interface{}, path []interface{}, opts Options) {
+	// --- container.name ---
+	if name, ok := container["name"].(string); !ok || name == "" {
+		r.errorf(RuleContainerName, subPath(path, "name"), "name is required")
+	} else if opts.ContainerName != nil && !opts.ContainerName.MatchString(name) {
+		r.errorf(RuleNameFormat, subPath(path, "name"), "name has invalid format '%s'", name)
+	}
+
+	// --- container.image ---
+	if image, ok := container["image"].(string); ok {
+		validateImage(r, image, subPath(path, "image"), opts)
+	}
+
+	// --- container.command / container.args ---
+	validateCommand(r, container, path)
+
+	// --- container.env ---
+	validateEnv(r, container, path)
+
+	// --- container.ports[].containerPort ---
+	if ports, ok := container["ports"].([]interface{}); ok {
+		seen := make(map[string]int) // "порт/протокол" — индекс первого объявления
+		for j, p := range ports {
+			if portObj, ok := p.(map[string]interface{}); ok {
+				if port, ok := portObj["containerPort"]; ok {
+					if !validatePort(port) {
+						r.errorf(RulePortRange, subPath(path, "ports", j, "containerPort"), "containerPort value out of range")
+					}
+
+					// --- повтор containerPort с тем же протоколом ---
+					protocol := "TCP"
+					if value, ok := portObj["protocol"]; ok {
+						protocol = fmt.Sprint(value)
+					}
+					key := fmt.Sprintf("%v/%s", port, protocol)
+					if first, ok := seen[key]; ok {
+						r.relatedf(RulePortDuplicate, subPath(path, "ports", j, "containerPort"),
+							[]Location{r.location(subPath(path, "ports", first, "containerPort"), "first declared here")},
+							"containerPort %s is duplicated", key)
+					} else {
+						seen[key] = j
+					}
+				}
+
+				// --- container.ports[].protocol ---
+				if _, ok := portObj["protocol"]; !ok && opts.RequireProtocol {
+					r.errorf(RulePortProtocol, subPath(path, "ports", j), "protocol is required")
+				}
+			}
+		}
+	}
+
+	// --- readinessProbe / livenessProbe / startupProbe ---
+	for _, name := range []string{"readinessProbe", "livenessProbe", "startupProbe"} {
+		if probe, ok := container[name].(map[string]interface{}); ok {
+			validateProbe(r, probe, name, subPath(path, name))
+		}
+	}
+
+	// --- resources ---
+	if resources, ok := container["resources"].(map[string]interface{}); ok {
+		// --- допустимые ключи limits/requests ---
+		if len(opts.ResourceKeys) > 0 {
+			for _, section := range []string{"limits", "requests"} {
+				values, _ := resources[section].(map[string]interface{})
+				for _, key := range sortedKeys(values) {
+					if !allowedResourceKey(key, opts.ResourceKeys) {
+						r.keyErrorf(RuleResourceKey, subPath(path, "resources", section, key), "%s has unsupported key '%s'", section, key)
+					}
+				}
+			}
+		}
+
+		// --- limits/requests: cpu и memory ---
+		for _, section := range []string{"limits", "requests"} {
+			values, _ := resources[section].(map[string]interface{})
+			for _, key := range []string{"cpu", "memory"} {
+				value, ok := values[key]
+				if !ok {
+					continue
+				}
+				if q, err := quantity.Parse(fmt.Sprint(value)); err != nil || q.Sign() < 0 {
+					r.errorf(RuleQuantity, subPath(path, "resources", section, key), "%s has invalid format '%v'", key, value)
+				}
+			}
+		}
+
+		// --- requests не больше limits ---
+		requests, _ := resources["requests"].(map[string]interface{})
+		limits, _ := resources["limits"].(map[string]interface{})
+		for _, key := range sortedKeys(requests) {
+			limit, ok := limits[key]
+			if !ok {
+				continue
+			}
+			req, err := quantity.Parse(fmt.Sprint(requests[key]))
+			if err != nil {
+				continue
+			}
+			lim, err := quantity.Parse(fmt.Sprint(limit))
+			if err == nil && req.Cmp(lim) > 0 {
+				r.relatedf(RuleRequestLimit, subPath(path, "resources", "requests", key),
+					[]Location{r.location(subPath(path, "resources", "limits", key), fmt.Sprintf("limits.%s is %v", key, limit))},
+					"requests.%s %v exceeds limits.%s %v", key, requests[key], key, limit)
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package podlint
+
+import (
+	"regexp"
+	"testing"
+)
+
+var checkRuleCases = []ruleCase{
+	{rule: RuleFieldRequired, yaml: podMeta("\n  name: web\n  namespace: prod", "\n  containers: []"),
+		opts: func(o *Options) { o.RequiredFields = []string{"metadata.namespace"} }},
+	{rule: RuleFieldRequired, fail: true, yaml: validPod,
+		opts: func(o *Options) { o.RequiredFields = []string{"metadata.namespace"} }},
+
+	{rule: RuleNameFormat, yaml: validPod},
+	{rule: RuleNameFormat, fail: true, yaml: pod("\n  containers:\n    - name: Web_Server\n      image: web:1")},
+	{rule: RuleNameFormat, fail: true, yaml: pod("\n  containers:\n    - name: web-server\n      image: web:1"),
+		opts: func(o *Options) { o.ContainerName = regexp.MustCompile(`^[a-z]+(_[a-z]+)*$`) }},
+
+	{rule: RuleImageRegistry, yaml: validPod,
+		opts: func(o *Options) { o.Registries = []string{"registry.example.com/"} }},
+	{rule: RuleImageRegistry, yaml: pod("\n  containers:\n    - name: web\n      image: nginx:1.0")},
+	{rule: RuleImageRegistry, fail: true, yaml: pod("\n  containers:\n    - name: web\n      image: nginx:1.0"),
+		opts: func(o *Options) { o.Registries = []string{"registry.example.com/"} }},
+
+	{rule: RuleImageTag, yaml: pod("\n  containers:\n    - name: web\n      image: localhost:5000/web@sha256:abc")},
+	{rule: RuleImageTag, fail: true, yaml: pod("\n  containers:\n    - name: web\n      image: localhost:5000/web")},
+
+	{rule: RuleImageEnvTag, yaml: validPod,
+		opts: func(o *Options) { o.ImageTag = regexp.MustCompile(`^1\.`) }},
+	{rule: RuleImageEnvTag, fail: true, yaml: validPod,
+		opts: func(o *Options) { o.ImageTag = regexp.MustCompile(`^2\.`) }},
+
+	{rule: RuleImageLatest, yaml: validPod,
+		opts: func(o *Options) { o.DenyLatest = true }},
+	{rule: RuleImageLatest, fail: true, yaml: pod("\n  containers:\n    - name: web\n      image: nginx:latest"),
+		opts: func(o *Options) { o.DenyLatest = true }},
+
+	{rule: RuleImageSemver, yaml: validPod,
+		opts: func(o *Options) { o.SemverTags = true }},
+	{rule: RuleImageSemver, fail: true, yaml: pod("\n  containers:\n    - name: web\n      image: nginx:stable"),
+		opts: func(o *Options) { o.SemverTags = true }},
+
+	{rule: RuleImageDigest, yaml: pod("\n  containers:\n    - name: web\n      image: nginx:1.0@sha256:abc"),
+		opts: func(o *Options) { o.RequireDigest = true }},
+	{rule: RuleImageDigest, fail: true, yaml: validPod,
+		opts: func(o *Options) { o.RequireDigest = true }},
+
+	{rule: RulePortDuplicate, yaml: container("\n      ports:\n        - containerPort: 53\n        - containerPort: 53\n          protocol: UDP")},
+	{rule: RulePortDuplicate, fail: true, yaml: container("\n      ports:\n        - containerPort: 8080\n        - containerPort: 8080\n          protocol: TCP")},
+
+	{rule: RuleInitProbe, yaml: pod("\n  initContainers:\n    - name: proxy\n      image: proxy:1\n      restartPolicy: Always" +
+		"\n      readinessProbe:\n        tcpSocket: {port: 15000}\n  containers: []")},
+	{rule: RuleInitProbe, fail: true, yaml: pod("\n  initContainers:\n    - name: init\n      image: init:1" +
+		"\n      readinessProbe:\n        tcpSocket: {port: 15000}\n  containers: []")},
+
+	{rule: RuleInitSharedRW, yaml: pod("\n  volumes:\n    - name: data\n      emptyDir: {}" +
+		"\n  initContainers:\n    - name: init\n      image: init:1\n      volumeMounts: [{name: data, mountPath: /data}]" +
+		"\n  containers:\n    - name: web\n      image: web:1\n      volumeMounts: [{name: data, mountPath: /data, readOnly: true}]")},
+	{rule: RuleInitSharedRW, fail: true, yaml: pod("\n  volumes:\n    - name: data\n      emptyDir: {}" +
+		"\n  initContainers:\n    - name: init\n      image: init:1\n      volumeMounts: [{name: data, mountPath: /data}]" +
+		"\n  containers:\n    - name: web\n      image: web:1\n      volumeMounts: [{name: data, mountPath: /data}]")},
+
+	{rule: RuleEphemeral, yaml: pod("\n  ephemeralContainers:\n    - name: debug\n      image: busybox:1\n  containers: []")},
+	{rule: RuleEphemeral, fail: true, yaml: pod("\n  ephemeralContainers:\n    - name: debug\n      image: busybox:1\n      ports: [{containerPort: 80}]\n  containers: []")},
+
+	{rule: RuleQuantity, yaml: validPod},
+	{rule: RuleQuantity, fail: true, yaml: container("\n      resources:\n        limits: {cpu: lots}")},
+	{rule: RuleQuantity, fail: true, yaml: container("\n      resources:\n        requests: {memory: -1Gi}")},
+
+	{rule: RuleResourceKey, yaml: container("\n      resources:\n        limits: {cpu: 1, example.com/gpu: 1}"),
+		opts: func(o *Options) { o.ResourceKeys = []string{"cpu", "memory"} }},
+	{rule: RuleResourceKey, yaml: container("\n      resources:\n        limits: {gpu: 1}")},
+	{rule: RuleResourceKey, fail: true, yaml: container("\n      resources:\n        limits: {gpu: 1}"),
+		opts: func(o *Options) { o.ResourceKeys = []string{"cpu", "memory"} }},
+
+	{rule: RuleRequestLimit, yaml: validPod},
+	{rule: RuleRequestLimit, fail: true, yaml: container("\n      resources:\n        requests: {cpu: 1}\n        limits: {cpu: 500m}")},
+}
+
+func TestCheckRules(t *testing.T) {
+	testRuleCases(t, checkRuleCases)
+}
@@ -0,0 +1,77 @@
+package podlint
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Ссылка на переменную в синтаксисе Docker/shell: $VAR или ${VAR}.
+// Kubernetes подставляет в command и args только $(VAR), "$$" — экранирование.
+var shellVarRef = regexp.MustCompile(`\$\$|\$\{?[A-Za-z_][A-Za-z0-9_]*\}?`)
+
+// Командные оболочки, которым скрипт передаётся через -c
+var shells = map[string]bool{"sh": true, "bash": true, "ash": true, "dash": true, "zsh": true}
+
+// Эвристики command/args: команда одной строкой с аргументами, несколько
+// флагов в одном элементе и ссылки на переменные не в синтаксисе $(VAR)
+func validateCommand(r *reporter, container map[string]interface{}, containerPath []interface{}) {
+	command := stringList(container["command"])
+	args := stringList(container["args"])
+
+	// --- command одной строкой вместе с args ---
+	if len(command) == 1 && len(args) > 0 && strings.ContainsAny(command[0], " \t") {
+		r.errorf(RuleCommandString, subPath(containerPath, "command", 0),
+			"command '%s' is a single string, args are passed to it as separate words", command[0])
+	}
+
+	// Скрипт оболочки (элемент после "-c") разбирается самой оболочкой,
+	// в нём допустимы и "--", и $VAR
+	all := append(append([]string(nil), command...), args...)
+	script := -1
+	if len(all) > 0 && shells[path.Base(all[0])] {
+		for i := 1; i < len(all)-1; i++ {
+			if all[i] == "-c" {
+				script = i + 1
+				break
+			}
+		}
+	}
+
+	for i, item := range all {
+		if i == script {
+			continue
+		}
+		field, index := "command", i
+		if i >= len(command) {
+			field, index = "args", i-len(command)
+		}
+
+		// --- несколько флагов в одном элементе ---
+		if strings.HasPrefix(item, "-") && strings.Contains(item, " --") {
+			r.errorf(RuleCommandSplit, subPath(containerPath, field, index),
+				"%s item '%s' contains several flags, split it into separate items", field, item)
+		}
+
+		// --- $VAR вместо $(VAR) ---
+		for _, ref := range shellVarRef.FindAllString(item, -1) {
+			if ref == "$$" {
+				continue
+			}
+			name := strings.Trim(ref, "${}")
+			r.errorf(RuleCommandVarRef, subPath(containerPath, field, index),
+				"%s uses '%s', Kubernetes only expands $(%s)", field, ref, name)
+		}
+	}
+}
+
+// Элементы-строки списка YAML
+func stringList(value interface{}) []string {
+	list, _ := value.([]interface{})
+	items := make([]string, 0, len(list))
+	for _, item := range list {
+		s, _ := item.(string)
+		items = append(items, s)
+	}
+	return items
+}
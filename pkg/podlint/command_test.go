@@ -0,0 +1,19 @@
+package podlint
+
+import "testing"
+
+var commandRuleCases = []ruleCase{
+	{rule: RuleCommandString, yaml: container("\n      command: [nginx]\n      args: [-g, daemon off;]")},
+	{rule: RuleCommandString, fail: true, yaml: container("\n      command: [nginx -g]\n      args: [daemon off;]")},
+
+	{rule: RuleCommandSplit, yaml: container("\n      args: [--port=80, --verbose]")},
+	{rule: RuleCommandSplit, yaml: container("\n      command: [sh, -c, \"run --port=80 --verbose\"]")},
+	{rule: RuleCommandSplit, fail: true, yaml: container("\n      args: [--port=80 --verbose]")},
+
+	{rule: RuleCommandVarRef, yaml: container("\n      args: [\"--home=$(HOME)\", \"--cost=$$5\"]")},
+	{rule: RuleCommandVarRef, fail: true, yaml: container("\n      args: [\"--home=${HOME}\"]")},
+}
+
+func TestCommandRules(t *testing.T) {
+	testRuleCases(t, commandRuleCases)
+}
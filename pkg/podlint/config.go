@@ -0,0 +1,291 @@
+package podlint
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+
+	"main.go/pkg/quantity"
+)
+
+// DefaultConfigFile — файл конфигурации, который CLI ищет в текущем каталоге.
+const DefaultConfigFile = ".yamlvalid.yaml"
+
+// Config — содержимое файла конфигурации. Незаданные поля оставляют
+// значения по умолчанию, пустой список явно снимает ограничение.
+type Config struct {
+	Registries     []string              `yaml:"registries,omitempty"`
+	Naming         NamingConfig          `yaml:"naming,omitempty"`
+	RequiredFields []string              `yaml:"requiredFields,omitempty"`
+	ResourceKeys   []string              `yaml:"resourceKeys,omitempty"`
+	Rules          map[string]RuleConfig `yaml:"rules,omitempty"`
+	Overrides      []OverrideConfig      `yaml:"overrides,omitempty"`
+	Budgets        BudgetConfig          `yaml:"budgets,omitempty"`
+	ImagePolicy    ImagePolicyConfig     `yaml:"imagePolicy,omitempty"`
+	Layout         string                `yaml:"layout,omitempty"` // шаблон пути, см. Options.PathLayout
+
+	// Аннотации, обязательные в metadata каждого документа (ключи могут
+	// содержать точки и "/", поэтому задаются отдельно от requiredFields)
+	RequiredAnnotations []string `yaml:"requiredAnnotations,omitempty"`
+
+	// Настройки окружений (prod, dev, ...), выбираются флагом --env
+	Environments map[string]EnvironmentConfig `yaml:"environments,omitempty"`
+
+	// Наборы правил по меткам пространств имён и файлы с манифестами
+	// Namespace, из которых берутся метки (шаблоны filepath.Glob)
+	PolicyPacks        []PolicyPackConfig `yaml:"policyPacks,omitempty"`
+	NamespaceManifests []string           `yaml:"namespaceManifests,omitempty"`
+
+	SkipKinds              []string `yaml:"skipKinds,omitempty"`              // документы этих kind не проверяются
+	IgnoreFilenamePatterns []string `yaml:"ignoreFilenamePatterns,omitempty"` // регулярные выражения для путей файлов
+}
+
+// NamingConfig — соглашения об именовании.
+type NamingConfig struct {
+	ContainerName *string `yaml:"containerName,omitempty"` // регулярное выражение, "" — без проверки
+}
+
+// ImagePolicyConfig — политика тегов образов, см. Options.DenyLatest и далее.
+type ImagePolicyConfig struct {
+	DenyLatest    bool `yaml:"denyLatest,omitempty"`
+	SemverTags    bool `yaml:"semverTags,omitempty"`
+	RequireDigest bool `yaml:"requireDigest,omitempty"`
+}
+
+// BudgetConfig — бюджеты пода и манифеста, 0 или "" — без ограничения.
+type BudgetConfig struct {
+	MaxContainers   int    `yaml:"maxContainers,omitempty"`
+	MaxPodCPU       string `yaml:"maxPodCPU,omitempty"`    // величина Kubernetes, например "4" или "3500m"
+	MaxPodMemory    string `yaml:"maxPodMemory,omitempty"` // например "8Gi"
+	MaxManifestSize int64  `yaml:"maxManifestSize,omitempty"`
+}
+
+// EnvironmentConfig — настройки одного окружения.
+type EnvironmentConfig struct {
+	ImageTag string `yaml:"imageTag,omitempty"` // регулярное выражение для тегов образов
+}
+
+// OverrideConfig — настройки правил только для части документов: по
+// шаблонам путей файлов ("jobs/**"), kind и пространствам имён.
+type OverrideConfig struct {
+	Files      []string              `yaml:"files,omitempty"`
+	Kinds      []string              `yaml:"kinds,omitempty"`
+	Namespaces []string              `yaml:"namespaces,omitempty"`
+	Rules      map[string]RuleConfig `yaml:"rules"`
+}
+
+// PolicyPackConfig — набор правил для пространств имён, метки которых
+// содержат все пары namespaceLabels, например tier: regulated.
+type PolicyPackConfig struct {
+	Name            string                `yaml:"name"`
+	NamespaceLabels map[string]string     `yaml:"namespaceLabels"`
+	Rules           map[string]RuleConfig `yaml:"rules"`
+}
+
+// RuleConfig — настройки отдельного правила.
+type RuleConfig struct {
+	Disabled *bool  `yaml:"disabled,omitempty"` // false включает правило, выключенное по умолчанию
+	Severity string `yaml:"severity,omitempty"` // SeverityError или SeverityWarning
+}
+
+// LoadConfig читает и проверяет файл конфигурации: неизвестные ключи,
+// некорректные регулярные выражения и несуществующие правила — ошибка.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if cfg.Naming.ContainerName != nil && *cfg.Naming.ContainerName != "" {
+		if _, err := regexp.Compile(*cfg.Naming.ContainerName); err != nil {
+			return nil, fmt.Errorf("%s: naming.containerName: %w", path, err)
+		}
+	}
+	for _, pattern := range cfg.IgnoreFilenamePatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("%s: ignoreFilenamePatterns: %w", path, err)
+		}
+	}
+	for field, value := range map[string]string{"maxPodCPU": cfg.Budgets.MaxPodCPU, "maxPodMemory": cfg.Budgets.MaxPodMemory} {
+		if value == "" {
+			continue
+		}
+		if _, err := quantity.Parse(value); err != nil {
+			return nil, fmt.Errorf("%s: budgets.%s: %w", path, field, err)
+		}
+	}
+	if cfg.Layout != "" {
+		if _, err := layoutRegexp(cfg.Layout); err != nil {
+			return nil, fmt.Errorf("%s: layout: %w", path, err)
+		}
+	}
+	for name, env := range cfg.Environments {
+		if _, err := regexp.Compile(env.ImageTag); err != nil {
+			return nil, fmt.Errorf("%s: environments.%s.imageTag: %w", path, name, err)
+		}
+	}
+	for i, key := range cfg.RequiredAnnotations {
+		if reason := labelKeyError(key); reason != "" {
+			return nil, fmt.Errorf("%s: requiredAnnotations[%d]: key '%s' %s", path, i, key, reason)
+		}
+	}
+	if err := checkRules("rules", cfg.Rules); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i, o := range cfg.Overrides {
+		if err := checkRules(fmt.Sprintf("overrides[%d].rules", i), o.Rules); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	for i, p := range cfg.PolicyPacks {
+		if p.Name == "" {
+			return nil, fmt.Errorf("%s: policyPacks[%d].name is required", path, i)
+		}
+		if len(p.NamespaceLabels) == 0 {
+			return nil, fmt.Errorf("%s: policyPacks[%d].namespaceLabels is required", path, i)
+		}
+		if err := checkRules(fmt.Sprintf("policyPacks[%d].rules", i), p.Rules); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return &cfg, nil
+}
+
+// Проверка настроек правил: существующие правила и уровни серьёзности
+func checkRules(field string, rules map[string]RuleConfig) error {
+	for id, rc := range rules {
+		if _, ok := LookupRule(id); !ok {
+			return fmt.Errorf("%s: unknown rule '%s'", field, id)
+		}
+		if rc.Severity != "" && rc.Severity != SeverityError && rc.Severity != SeverityWarning {
+			return fmt.Errorf("%s.%s.severity: unknown severity '%s', expected error or warning", field, id, rc.Severity)
+		}
+	}
+	return nil
+}
+
+// Apply переносит заданные в конфигурации значения в opts.
+func (c *Config) Apply(opts *Options) error {
+	if c.Registries != nil {
+		opts.Registries = c.Registries
+	}
+	if c.Naming.ContainerName != nil {
+		opts.ContainerName = nil
+		if *c.Naming.ContainerName != "" {
+			re, err := regexp.Compile(*c.Naming.ContainerName)
+			if err != nil {
+				return fmt.Errorf("naming.containerName: %w", err)
+			}
+			opts.ContainerName = re
+		}
+	}
+	if c.RequiredFields != nil {
+		opts.RequiredFields = c.RequiredFields
+	}
+	if c.RequiredAnnotations != nil {
+		opts.RequiredAnnotations = c.RequiredAnnotations
+	}
+	if c.ResourceKeys != nil {
+		opts.ResourceKeys = c.ResourceKeys
+	}
+	if c.SkipKinds != nil {
+		opts.SkipKinds = c.SkipKinds
+	}
+	if c.Layout != "" {
+		opts.PathLayout = c.Layout
+	}
+	opts.DenyLatest = opts.DenyLatest || c.ImagePolicy.DenyLatest
+	opts.SemverTags = opts.SemverTags || c.ImagePolicy.SemverTags
+	opts.RequireDigest = opts.RequireDigest || c.ImagePolicy.RequireDigest
+	if c.Budgets.MaxContainers != 0 {
+		opts.MaxContainers = c.Budgets.MaxContainers
+	}
+	if c.Budgets.MaxPodCPU != "" {
+		q, err := quantity.Parse(c.Budgets.MaxPodCPU)
+		if err != nil {
+			return fmt.Errorf("budgets.maxPodCPU: %w", err)
+		}
+		opts.MaxPodCPU = q
+	}
+	if c.Budgets.MaxPodMemory != "" {
+		q, err := quantity.Parse(c.Budgets.MaxPodMemory)
+		if err != nil {
+			return fmt.Errorf("budgets.maxPodMemory: %w", err)
+		}
+		opts.MaxPodMemory = q
+	}
+	if c.Budgets.MaxManifestSize != 0 {
+		opts.MaxManifestSize = c.Budgets.MaxManifestSize
+	}
+	for _, pattern := range c.IgnoreFilenamePatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("ignoreFilenamePatterns: %w", err)
+		}
+		opts.IgnoreFilenames = append(opts.IgnoreFilenames, re)
+	}
+	applyRules(c.Rules, &opts.DisabledRules, &opts.Severities)
+	for _, p := range c.PolicyPacks {
+		pack := PolicyPack{Name: p.Name, Selector: p.NamespaceLabels}
+		applyRules(p.Rules, &pack.DisabledRules, &pack.Severities)
+		opts.Packs = append(opts.Packs, pack)
+	}
+	if len(c.NamespaceManifests) > 0 {
+		namespaces, err := LoadNamespaces(c.NamespaceManifests)
+		if err != nil {
+			return fmt.Errorf("namespaceManifests: %w", err)
+		}
+		opts.NamespaceLabels = namespaces
+	}
+	for _, o := range c.Overrides {
+		override := RuleOverride{Files: o.Files, Kinds: o.Kinds, Namespaces: o.Namespaces}
+		applyRules(o.Rules, &override.DisabledRules, &override.Severities)
+		opts.Overrides = append(opts.Overrides, override)
+	}
+	return nil
+}
+
+// Перенос настроек правил в карты отключённых правил и уровней серьёзности
+func applyRules(rules map[string]RuleConfig, disabled *map[string]bool, severities *map[string]string) {
+	for id, rc := range rules {
+		if rc.Disabled != nil {
+			if *disabled == nil {
+				*disabled = make(map[string]bool)
+			}
+			(*disabled)[id] = *rc.Disabled
+		}
+		if rc.Severity != "" {
+			if *severities == nil {
+				*severities = make(map[string]string)
+			}
+			(*severities)[id] = rc.Severity
+		}
+	}
+}
+
+// ApplyEnvironment переносит в opts настройки окружения name.
+func (c *Config) ApplyEnvironment(name string, opts *Options) error {
+	env, ok := c.Environments[name]
+	if !ok {
+		return fmt.Errorf("unknown environment '%s'", name)
+	}
+	opts.ImageTag = nil
+	if env.ImageTag != "" {
+		re, err := regexp.Compile(env.ImageTag)
+		if err != nil {
+			return fmt.Errorf("environments.%s.imageTag: %w", name, err)
+		}
+		opts.ImageTag = re
+	}
+	return nil
+}
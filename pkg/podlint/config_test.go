@@ -0,0 +1,108 @@
+package podlint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Параметры из файла конфигурации с содержимым config поверх DefaultOptions
+func configOptions(t *testing.T, config string) Options {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := DefaultOptions()
+	if err := cfg.Apply(&opts); err != nil {
+		t.Fatal(err)
+	}
+	return opts
+}
+
+// Правило и уровень нарушений в errs
+func ruleSeverities(errs []ValidationError) map[string]string {
+	severities := make(map[string]string)
+	for _, e := range errs {
+		severities[e.RuleID] = e.Severity
+	}
+	return severities
+}
+
+var untaggedPod = podMeta("\n  name: web\n  namespace: prod", "\n  containers:\n    - name: web\n      image: nginx")
+
+func TestConfigRules(t *testing.T) {
+	opts := configOptions(t, `
+registries: [registry.example.com/]
+naming:
+  containerName: "^[a-z]+$"
+rules:
+  IMG002: {severity: warning}
+  SEC002: {disabled: false}
+`)
+	got := ruleSeverities(ValidateBytes("pod.yaml", []byte(untaggedPod), opts))
+	want := map[string]string{
+		RuleImageRegistry: SeverityError,
+		RuleImageTag:      SeverityWarning,
+		RuleRunAsNonRoot:  SeverityWarning,
+	}
+	for rule, severity := range want {
+		if got[rule] != severity {
+			t.Errorf("%s: severity %q, want %q (findings %v)", rule, got[rule], severity, got)
+		}
+	}
+	if _, ok := got[RuleEscalation]; ok {
+		t.Errorf("%s is disabled by default", RuleEscalation)
+	}
+}
+
+func TestConfigOverrides(t *testing.T) {
+	opts := configOptions(t, `
+overrides:
+  - files: ["legacy/**"]
+    rules:
+      IMG002: {disabled: true}
+  - kinds: [Pod]
+    namespaces: [prod]
+    rules:
+      IMG002: {severity: warning}
+`)
+	tests := []struct {
+		file string
+		yaml string
+		want string // уровень IMG002, "" — нарушения нет
+	}{
+		{"legacy/web/pod.yaml", untaggedPod, ""},
+		{"apps/pod.yaml", untaggedPod, SeverityWarning},
+		{"apps/pod.yaml", strings.Replace(untaggedPod, "namespace: prod", "namespace: dev", 1), SeverityError},
+		// Уровень из второго переопределения не включает отключённое первым правило
+		{"legacy/pod.yaml", untaggedPod, ""},
+	}
+	for _, tt := range tests {
+		got := ruleSeverities(ValidateBytes(tt.file, []byte(tt.yaml), opts))[RuleImageTag]
+		if got != tt.want {
+			t.Errorf("%s: %s severity %q, want %q", tt.file, RuleImageTag, got, tt.want)
+		}
+	}
+}
+
+// Disabled показывает, какие правила отключены для каждого документа
+func TestFileResultDisabled(t *testing.T) {
+	opts := configOptions(t, "overrides:\n  - files: [\"jobs/**\"]\n    rules:\n      PRB002: {disabled: true}\n")
+	opts.SkipKinds = []string{"ConfigMap"}
+	data := validPod + "---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cfg\n"
+	for file, want := range map[string]bool{"jobs/pod.yaml": true, "apps/pod.yaml": false} {
+		res := ValidateFile(file, []byte(data), opts)
+		if len(res.Disabled) != 1 {
+			t.Fatalf("%s: expected 1 checked document, got %d", file, len(res.Disabled))
+		}
+		if got := res.Disabled[0][RuleProbeHandler]; got != want {
+			t.Errorf("%s: %s disabled = %v, want %v", file, RuleProbeHandler, got, want)
+		}
+	}
+}
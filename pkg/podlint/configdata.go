@@ -0,0 +1,84 @@
+package podlint
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Ограничение Kubernetes на размер данных ConfigMap и Secret
+const maxDataSize = 1 << 20
+
+// Допустимый ключ data: буквы, цифры, "-", "_" и "."
+var dataKey = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+// Значения-заглушки, которые подставляются при развёртывании: ${VAR},
+// {{ .Values.x }}, <password>, CHANGEME
+var placeholder = regexp.MustCompile(`^(\$\{[^}]*\}|\{\{.*\}\}|<[^>]*>|(?i:changeme|replace_?me|todo|xxx+))$`)
+
+// Проверка ConfigMap и Secret: ключи, кодировка, размер и открытые секреты
+func validateConfigData(r *reporter, kind string, raw map[string]interface{}) {
+	// Поля с данными; значения в base64 — data у Secret и binaryData у ConfigMap
+	fields := []string{"data", "binaryData"}
+	encoded := map[string]bool{"binaryData": true}
+	if kind == "Secret" {
+		fields = []string{"data", "stringData"}
+		encoded = map[string]bool{"data": true}
+	}
+
+	size := 0
+	seen := make(map[string]string)
+	for _, field := range fields {
+		values, _ := raw[field].(map[string]interface{})
+		for _, key := range sortedKeys(values) {
+			path := []interface{}{field, key}
+
+			// --- ключ ---
+			switch {
+			case len(key) > 253 || !dataKey.MatchString(key) || key == "." || key == "..":
+				r.keyErrorf(RuleDataKey, path, "%s has invalid key '%s'", field, key)
+			case seen[key] != "" && kind == "ConfigMap":
+				r.keyErrorf(RuleDataKey, path, "key '%s' is duplicated in %s", key, seen[key])
+			}
+			seen[key] = field
+
+			// --- значение ---
+			value, _ := values[key].(string)
+			if encoded[field] {
+				decoded, err := base64.StdEncoding.DecodeString(value)
+				if err != nil {
+					r.errorf(RuleDataEncoding, path, "%s value of '%s' is not valid base64", field, key)
+					continue
+				}
+				value = string(decoded)
+			}
+			size += len(key) + len(value)
+
+			// --- открытый секрет ---
+			if kind == "Secret" && looksLikeCredential(value) {
+				r.errorf(RulePlaintext, path, "value of '%s' looks like a credential committed in plain form, use an external secret store", key)
+			}
+		}
+	}
+
+	// --- размер ---
+	if size > maxDataSize {
+		r.errorf(RuleDataSize, nil, "%s data size %d exceeds limit of %d bytes", kind, size, maxDataSize)
+	}
+}
+
+// Похоже ли значение на настоящий секрет: непустой печатный текст, а не заглушка
+func looksLikeCredential(value string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" || placeholder.MatchString(value) || !utf8.ValidString(value) {
+		return false
+	}
+	for _, c := range value {
+		if !unicode.IsPrint(c) && !unicode.IsSpace(c) {
+			return false
+		}
+	}
+	return true
+}
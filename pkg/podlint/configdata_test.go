@@ -0,0 +1,25 @@
+package podlint
+
+import (
+	"strings"
+	"testing"
+)
+
+var configDataRuleCases = []ruleCase{
+	{rule: RuleDataKey, yaml: configData("ConfigMap", "\ndata:\n  app.properties: x=1")},
+	{rule: RuleDataKey, fail: true, yaml: configData("ConfigMap", "\ndata:\n  app properties: x=1")},
+	{rule: RuleDataKey, fail: true, yaml: configData("ConfigMap", "\ndata:\n  a: x\nbinaryData:\n  a: eA==")},
+
+	{rule: RuleDataEncoding, yaml: configData("Secret", "\ndata:\n  token: JHtUT0tFTn0=")},
+	{rule: RuleDataEncoding, fail: true, yaml: configData("Secret", "\ndata:\n  token: not base64!")},
+
+	{rule: RuleDataSize, yaml: configData("ConfigMap", "\ndata:\n  a: "+strings.Repeat("x", 1000))},
+	{rule: RuleDataSize, fail: true, yaml: configData("ConfigMap", "\ndata:\n  a: "+strings.Repeat("x", maxDataSize))},
+
+	{rule: RulePlaintext, yaml: configData("Secret", "\nstringData:\n  password: ${DB_PASSWORD}")},
+	{rule: RulePlaintext, fail: true, yaml: configData("Secret", "\nstringData:\n  password: hunter2")},
+}
+
+func TestConfigDataRules(t *testing.T) {
+	testRuleCases(t, configDataRuleCases)
+}
@@ -0,0 +1,88 @@
+package podlint
+
+// Зависимости правил: нарушение правила-ключа не сообщается, если в той же
+// области уже есть нарушение одного из перечисленных правил — оно и есть
+// первопричина. Например, при неверном формате образа нет смысла проверять
+// политику тегов, а у недопустимого порта — его повторы.
+var ruleDependencies = map[string][]string{
+	RuleNameFormat:    {RuleContainerName},
+	RuleContainerDup:  {RuleContainerName},
+	RuleImageLatest:   {RuleImageTag},
+	RuleImageSemver:   {RuleImageTag},
+	RuleImageEnvTag:   {RuleImageTag},
+	RuleImageDigest:   {RuleImageTag},
+	RulePortDuplicate: {RulePortRange},
+	RulePortConflict:  {RulePortRange},
+	RuleEscalation:    {RulePrivileged},
+	RuleRequestLimit:  {RuleQuantity},
+	RulePodBudget:     {RuleQuantity},
+}
+
+// RuleDependencies возвращает правила, нарушение которых подавляет rule.
+func RuleDependencies(rule string) []string {
+	return ruleDependencies[rule]
+}
+
+// Область, в которой нарушение подавляет зависимые правила: контейнер, если
+// путь ведёт внутрь контейнера, иначе весь документ (nil)
+func suppressionScope(path []interface{}) []interface{} {
+	for i := 0; i+1 < len(path); i++ {
+		switch path[i] {
+		case "containers", "initContainers", "ephemeralContainers":
+			if _, ok := path[i+1].(int); ok {
+				return path[:i+2]
+			}
+		}
+	}
+	return nil
+}
+
+// Является ли prefix началом пути path
+func hasPathPrefix(path, prefix []interface{}) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Перенос нарушений, первопричина которых уже сообщена, из findings в
+// suppressed с отметкой SuppressedBy. Вызывается после всех проверок
+// документа, поэтому порядок проверок не важен.
+func (r *reporter) suppress() {
+	causes := make([]string, len(r.findings))
+	for i, f := range r.findings {
+		causes[i] = r.cause(f.RuleID, f.path)
+	}
+	kept := r.findings[:0]
+	for i, f := range r.findings {
+		if causes[i] != "" {
+			f.SuppressedBy = causes[i]
+			r.suppressed = append(r.suppressed, f)
+			continue
+		}
+		kept = append(kept, f)
+	}
+	r.findings = kept
+}
+
+// Правило-первопричина для нарушения rule в поле path или "". Первопричина
+// находится в той же области или внутри path: нарушение BUD002 о
+// spec.containers подавляется неверной величиной в любом из контейнеров.
+func (r *reporter) cause(rule string, path []interface{}) string {
+	for _, dep := range ruleDependencies[rule] {
+		for _, f := range r.findings {
+			if f.RuleID != dep {
+				continue
+			}
+			if hasPathPrefix(path, suppressionScope(f.path)) || hasPathPrefix(f.path, path) {
+				return dep
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+package podlint
+
+import (
+	"testing"
+)
+
+func TestSuppression(t *testing.T) {
+	tests := []struct {
+		name       string
+		yaml       string
+		opts       func(*Options)
+		errors     []string // правила нарушений в Errors
+		suppressed []string // правила подавленных нарушений
+		causes     []string // SuppressedBy подавленных нарушений
+	}{
+		{
+			name:       "untagged image is not checked for digest",
+			yaml:       pod("\n  containers:\n    - name: web\n      image: nginx"),
+			opts:       func(o *Options) { o.RequireDigest = true },
+			errors:     []string{RuleImageTag},
+			suppressed: []string{RuleImageDigest},
+			causes:     []string{RuleImageTag},
+		},
+		{
+			name:       "invalid port is not reported as duplicate",
+			yaml:       container("\n      ports:\n        - containerPort: 0\n        - containerPort: 0"),
+			errors:     []string{RulePortRange, RulePortRange},
+			suppressed: []string{RulePortDuplicate},
+			causes:     []string{RulePortRange},
+		},
+		{
+			name: "cause in another container does not suppress",
+			yaml: pod("\n  containers:\n    - name: a\n      image: a:1\n      ports: [{containerPort: 0}]" +
+				"\n    - name: b\n      image: b:1\n      ports: [{containerPort: 8080}, {containerPort: 8080}]"),
+			errors: []string{RulePortRange, RulePortDuplicate},
+		},
+		{
+			name:       "invalid quantity suppresses request check in the same container",
+			yaml:       container("\n      resources:\n        requests: {cpu: lots, memory: 2Gi}\n        limits: {memory: 1Gi}"),
+			errors:     []string{RuleQuantity},
+			suppressed: []string{RuleRequestLimit},
+			causes:     []string{RuleQuantity},
+		},
+		{
+			name: "invalid quantity in any container suppresses pod budget",
+			yaml: pod("\n  containers:\n    - name: a\n      image: a:1\n      resources: {limits: {cpu: 2}}" +
+				"\n    - name: b\n      image: b:1\n      resources: {limits: {cpu: lots}}"),
+			opts:       func(o *Options) { o.MaxPodCPU = mustQuantity("1") },
+			errors:     []string{RuleQuantity},
+			suppressed: []string{RulePodBudget},
+			causes:     []string{RuleQuantity},
+		},
+		{
+			name: "broken volume does not suppress mount of another volume",
+			yaml: pod("\n  volumes:\n    - name: data\n      emptyDir: {}\n      configMap: {name: cfg}" +
+				"\n  containers:\n    - name: web\n      image: web:1\n      volumeMounts: [{name: typo, mountPath: /data}]"),
+			errors: []string{RuleVolume, RuleVolumeMount},
+		},
+		{
+			name:   "disabled cause does not suppress",
+			yaml:   pod("\n  containers:\n    - name: web\n      image: nginx"),
+			opts:   func(o *Options) { o.RequireDigest = true; o.DisabledRules[RuleImageTag] = true },
+			errors: []string{RuleImageDigest},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			if tt.opts != nil {
+				tt.opts(&opts)
+			}
+			res := ValidateFile("pod.yaml", []byte(tt.yaml), opts)
+			var errors, suppressed, causes []string
+			for _, e := range res.Errors {
+				errors = append(errors, e.RuleID)
+			}
+			for _, e := range res.Suppressed {
+				suppressed = append(suppressed, e.RuleID)
+				causes = append(causes, e.SuppressedBy)
+			}
+			if !equalStrings(errors, tt.errors) || !equalStrings(suppressed, tt.suppressed) || !equalStrings(causes, tt.causes) {
+				t.Errorf("errors %v, suppressed %v by %v; want %v, %v by %v",
+					errors, suppressed, causes, tt.errors, tt.suppressed, tt.causes)
+			}
+
+			// Validate возвращает только неподавленные нарушения
+			docs, err := Parse([]byte(tt.yaml), opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := Validate(docs[0], opts); len(got) != len(tt.errors) {
+				t.Errorf("Validate returned %d findings, want %d", len(got), len(tt.errors))
+			}
+		})
+	}
+}
+
+func TestRuleDependencies(t *testing.T) {
+	for rule, deps := range ruleDependencies {
+		if _, ok := LookupRule(rule); !ok {
+			t.Errorf("unknown rule %s", rule)
+		}
+		for _, dep := range deps {
+			if _, ok := LookupRule(dep); !ok {
+				t.Errorf("%s depends on unknown rule %s", rule, dep)
+			}
+		}
+	}
+	if got := RuleDependencies(RuleImageDigest); !equalStrings(got, []string{RuleImageTag}) {
+		t.Errorf("RuleDependencies(%s) = %v", RuleImageDigest, got)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package podlint
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Проверки на уровне пода, которые не видны при проверке отдельного
+// контейнера: имена контейнеров и порты, общие для сетевого пространства пода,
+// а при hostNetwork — и для сети узла. Повтор имён томов проверяет
+// validateVolumes.
+func validateDuplicates(r *reporter, spec map[string]interface{}, path []interface{}) {
+	hostNetwork, _ := spec["hostNetwork"].(bool)
+
+	// Имя контейнера — путь к его первому объявлению
+	names := make(map[string][]interface{})
+	// "порт/протокол" — контейнер, который его объявил, и путь к порту
+	type declaration struct {
+		container string
+		path      []interface{}
+	}
+	ports := make(map[string]declaration)
+
+	for _, list := range []string{"initContainers", "containers", "ephemeralContainers"} {
+		containers, _ := spec[list].([]interface{})
+		for i, c := range containers {
+			container, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := container["name"].(string)
+
+			// --- имя контейнера уникально среди всех списков ---
+			if name != "" {
+				if first, ok := names[name]; ok {
+					r.relatedf(RuleContainerDup, subPath(path, list, i, "name"), []Location{r.location(first, "first declared here")},
+						"container name '%s' is duplicated in %s", name, fieldPath(first[len(path):len(path)+2]))
+				} else {
+					names[name] = subPath(path, list, i, "name")
+				}
+			}
+
+			// Порты слушают только обычные контейнеры и sidecar-контейнеры
+			if list == "ephemeralContainers" || list == "initContainers" && container["restartPolicy"] != "Always" {
+				continue
+			}
+
+			// --- порт с тем же протоколом в другом контейнере ---
+			declared, _ := container["ports"].([]interface{})
+			for j, p := range declared {
+				port, _ := p.(map[string]interface{})
+				value, ok := port["containerPort"]
+				if !ok {
+					continue
+				}
+				protocol := "TCP"
+				if v, ok := port["protocol"]; ok {
+					protocol = fmt.Sprint(v)
+				}
+				key := fmt.Sprintf("%v/%s", value, protocol)
+				portPath := subPath(path, list, i, "ports", j, "containerPort")
+
+				// --- порты в сети узла ---
+				if hostNetwork {
+					if hostPort, ok := port["hostPort"]; ok && fmt.Sprint(hostPort) != fmt.Sprint(value) {
+						r.errorf(RuleHostPort, subPath(path, list, i, "ports", j, "hostPort"),
+							"hostPort %v must equal containerPort %v when hostNetwork is true", hostPort, value)
+					}
+					if n, ok := value.(int); ok && n > 0 && n < 1024 && !canBindPrivileged(container) {
+						r.errorf(RuleLowPort, portPath,
+							"containerPort %d is a privileged port on the host network, add NET_BIND_SERVICE to securityContext.capabilities.add", n)
+					}
+				}
+				owner, ok := ports[key]
+				if ok && owner.container != name {
+					r.relatedf(RulePortConflict, portPath, []Location{r.location(owner.path, "declared by container '"+owner.container+"'")},
+						"containerPort %s is already declared by container '%s'", key, owner.container)
+				}
+				if !ok {
+					ports[key] = declaration{name, portPath}
+				}
+			}
+		}
+	}
+}
+
+// Может ли контейнер открыть порт меньше 1024: privileged или с
+// capability NET_BIND_SERVICE (или ALL)
+func canBindPrivileged(container map[string]interface{}) bool {
+	context, _ := container["securityContext"].(map[string]interface{})
+	if privileged, _ := context["privileged"].(bool); privileged {
+		return true
+	}
+	capabilities, _ := context["capabilities"].(map[string]interface{})
+	added, _ := capabilities["add"].([]interface{})
+	for _, c := range added {
+		switch strings.ToUpper(strings.TrimPrefix(fmt.Sprint(c), "CAP_")) {
+		case "NET_BIND_SERVICE", "ALL":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package podlint
+
+import "testing"
+
+var duplicateRuleCases = []ruleCase{
+	{rule: RuleContainerDup, yaml: pod("\n  initContainers:\n    - name: init\n      image: a:1\n  containers:\n    - name: web\n      image: a:1")},
+	{rule: RuleContainerDup, fail: true, yaml: pod("\n  initContainers:\n    - name: web\n      image: a:1\n  containers:\n    - name: web\n      image: a:1")},
+
+	{rule: RulePortConflict, yaml: pod("\n  containers:\n    - name: a\n      image: a:1\n      ports: [{containerPort: 8080}]" +
+		"\n    - name: b\n      image: b:1\n      ports: [{containerPort: 9090}]")},
+	{rule: RulePortConflict, fail: true, yaml: pod("\n  containers:\n    - name: a\n      image: a:1\n      ports: [{containerPort: 8080}]" +
+		"\n    - name: b\n      image: b:1\n      ports: [{containerPort: 8080}]")},
+
+	{rule: RuleLowPort, yaml: pod("\n  hostNetwork: true\n  containers:\n    - name: web\n      image: web:1\n      ports: [{containerPort: 80}]" +
+		"\n      securityContext:\n        capabilities: {add: [NET_BIND_SERVICE]}")},
+	{rule: RuleLowPort, yaml: pod("\n  containers:\n    - name: web\n      image: web:1\n      ports: [{containerPort: 80}]")},
+	{rule: RuleLowPort, fail: true, yaml: pod("\n  hostNetwork: true\n  containers:\n    - name: web\n      image: web:1\n      ports: [{containerPort: 80}]")},
+
+	{rule: RuleHostPort, yaml: pod("\n  hostNetwork: true\n  containers:\n    - name: web\n      image: web:1\n      ports: [{containerPort: 8080, hostPort: 8080}]")},
+	{rule: RuleHostPort, fail: true, yaml: pod("\n  hostNetwork: true\n  containers:\n    - name: web\n      image: web:1\n      ports: [{containerPort: 8080, hostPort: 9090}]")},
+}
+
+func TestDuplicateRules(t *testing.T) {
+	testRuleCases(t, duplicateRuleCases)
+}
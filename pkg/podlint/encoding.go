@@ -0,0 +1,61 @@
+package podlint
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// EncodingUTF8 — описание входных данных, которые не пришлось преобразовывать.
+const EncodingUTF8 = "UTF-8"
+
+// Normalize приводит содержимое файла к UTF-8 без BOM с переводами строк LF:
+// снимает BOM, декодирует UTF-16 (с BOM или без, по нулевым байтам в начале)
+// и заменяет CRLF на LF. Возвращает и описание исходной кодировки, например
+// "UTF-16LE with BOM, CRLF line endings". Данные, которые не удалось
+// декодировать, возвращаются ошибкой с понятным описанием.
+func Normalize(data []byte) ([]byte, string, error) {
+	encoding := EncodingUTF8
+	var order binary.ByteOrder
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		data, encoding = data[3:], "UTF-8 with BOM"
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		data, order, encoding = data[2:], binary.LittleEndian, "UTF-16LE with BOM"
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		data, order, encoding = data[2:], binary.BigEndian, "UTF-16BE with BOM"
+	case len(data) >= 2 && data[0] != 0 && data[1] == 0:
+		order, encoding = binary.LittleEndian, "UTF-16LE"
+	case len(data) >= 2 && data[0] == 0 && data[1] != 0:
+		order, encoding = binary.BigEndian, "UTF-16BE"
+	}
+
+	if order != nil {
+		if len(data)%2 != 0 {
+			return nil, encoding, fmt.Errorf("file looks like %s but has an odd number of bytes", encoding)
+		}
+		units := make([]uint16, len(data)/2)
+		for i := range units {
+			units[i] = order.Uint16(data[2*i:])
+		}
+		data = []byte(string(utf16.Decode(units)))
+	} else if !utf8.Valid(data) {
+		offset := 0
+		for offset < len(data) {
+			r, size := utf8.DecodeRune(data[offset:])
+			if r == utf8.RuneError && size == 1 {
+				break
+			}
+			offset += size
+		}
+		return nil, encoding, fmt.Errorf("file is not valid UTF-8 or UTF-16: invalid byte 0x%02X at offset %d", data[offset], offset)
+	}
+
+	if bytes.Contains(data, []byte("\r\n")) {
+		data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+		encoding += ", CRLF line endings"
+	}
+	return data, encoding, nil
+}
@@ -0,0 +1,17 @@
+package podlint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizedInput(t *testing.T) {
+	crlf := strings.ReplaceAll("\ufeff"+validPod, "\n", "\r\n")
+	res := ValidateFile("pod.yaml", []byte(crlf), DefaultOptions())
+	if len(res.Errors) > 0 {
+		t.Errorf("unexpected findings: %v", res.Errors)
+	}
+	if res.Encoding == EncodingUTF8 || len(res.Documents) != 1 {
+		t.Errorf("Encoding = %q, %d documents", res.Encoding, len(res.Documents))
+	}
+}
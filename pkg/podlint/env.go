@@ -0,0 +1,209 @@
+package podlint
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Переменные, которые Kubernetes создаёт для сервисов: FOO_SERVICE_HOST,
+// FOO_SERVICE_PORT[_NAME], FOO_PORT и FOO_PORT_80_TCP[_PROTO|_PORT|_ADDR]
+var serviceEnvName = regexp.MustCompile(`^[A-Z][A-Z0-9_]*_(SERVICE_HOST|SERVICE_PORT(_[A-Z0-9_]+)?|PORT|PORT_[0-9]+_(TCP|UDP|SCTP)(_PROTO|_PORT|_ADDR)?)$`)
+
+// Имя переменной окружения: идентификатор C
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Поля пода, доступные через valueFrom.fieldRef (кроме меток и аннотаций)
+var envFieldPaths = map[string]bool{
+	"metadata.name": true, "metadata.namespace": true, "metadata.uid": true,
+	"spec.nodeName": true, "spec.serviceAccountName": true,
+	"status.hostIP": true, "status.hostIPs": true, "status.podIP": true, "status.podIPs": true,
+}
+
+// Метка или аннотация: metadata.labels['key']
+var envFieldLabel = regexp.MustCompile(`^metadata\.(labels|annotations)\['[^']+'\]$`)
+
+// Ресурсы для valueFrom.resourceFieldRef
+var envResources = map[string]bool{
+	"limits.cpu": true, "limits.memory": true, "limits.ephemeral-storage": true,
+	"requests.cpu": true, "requests.memory": true, "requests.ephemeral-storage": true,
+}
+
+// Поле ссылки в envFrom для каждого kind источника
+var envFromRefs = map[string]string{"ConfigMap": "configMapRef", "Secret": "secretRef"}
+
+// Проверка переменных окружения контейнера: env и envFrom
+func validateEnv(r *reporter, container map[string]interface{}, path []interface{}) {
+	// Переменные из envFrom, которые удалось найти в документах файла
+	fromSources := make(map[string]string)
+	sources, _ := container["envFrom"].([]interface{})
+	for i, item := range sources {
+		source, _ := item.(map[string]interface{})
+		prefix, _ := source["prefix"].(string)
+
+		// --- envFrom[]: ровно один источник с именем ---
+		_, hasConfigMap := source["configMapRef"]
+		_, hasSecret := source["secretRef"]
+		if hasConfigMap == hasSecret {
+			r.errorf(RuleEnvSource, subPath(path, "envFrom", i), "envFrom must define exactly one of configMapRef or secretRef")
+		}
+		if prefix != "" && !envName.MatchString(prefix) {
+			r.errorf(RuleEnvName, subPath(path, "envFrom", i, "prefix"), "envFrom prefix has invalid format '%s'", prefix)
+		}
+
+		for _, kind := range []string{"ConfigMap", "Secret"} {
+			field := envFromRefs[kind]
+			ref, ok := source[field].(map[string]interface{})
+			name, _ := ref["name"].(string)
+			if ok && name == "" {
+				r.errorf(RuleEnvSource, subPath(path, "envFrom", i, field, "name"), "%s.name is required", field)
+			}
+			if name == "" {
+				continue
+			}
+			for _, key := range r.sourceKeys(kind, name) {
+				fromSources[prefix+key] = kind + " '" + name + "'"
+			}
+		}
+	}
+
+	env, _ := container["env"].([]interface{})
+	seen := make(map[string]int) // имя — индекс первого объявления
+	for i, item := range env {
+		variable, _ := item.(map[string]interface{})
+		name, _ := variable["name"].(string)
+
+		// --- env[].name ---
+		if name == "" {
+			r.errorf(RuleEnvName, subPath(path, "env", i, "name"), "name is required")
+			continue
+		}
+		if !envName.MatchString(name) {
+			r.errorf(RuleEnvName, subPath(path, "env", i, "name"), "env name has invalid format '%s'", name)
+		}
+		if first, ok := seen[name]; ok {
+			r.relatedf(RuleEnvName, subPath(path, "env", i, "name"),
+				[]Location{r.location(subPath(path, "env", first, "name"), "first declared here")},
+				"env '%s' is duplicated", name)
+		} else {
+			seen[name] = i
+		}
+
+		// --- env[].valueFrom ---
+		if valueFrom, ok := variable["valueFrom"].(map[string]interface{}); ok {
+			if _, ok := variable["value"]; ok {
+				r.errorf(RuleEnvSource, subPath(path, "env", i, "valueFrom"), "env '%s' must not set both value and valueFrom", name)
+			}
+			validateValueFrom(r, valueFrom, subPath(path, "env", i, "valueFrom"))
+		}
+
+		// --- совпадение с переменными сервисов ---
+		if serviceEnvName.MatchString(name) {
+			r.errorf(RuleEnvReserved, subPath(path, "env", i, "name"),
+				"env '%s' may collide with a service variable injected by Kubernetes", name)
+		}
+
+		// --- секрет в открытом виде ---
+		if value, ok := variable["value"].(string); ok && plaintextSecret(name, value) {
+			r.errorf(RuleEnvSecret, subPath(path, "env", i, "value"),
+				"env '%s' looks like a plaintext secret, use valueFrom.secretKeyRef", name)
+		}
+
+		// --- переопределение переменной из envFrom ---
+		if source, ok := fromSources[name]; ok {
+			r.errorf(RuleEnvOverride, subPath(path, "env", i, "name"),
+				"env '%s' overrides the value from %s in envFrom", name, source)
+		}
+	}
+}
+
+// Проверка valueFrom: ровно один источник и его обязательные поля
+func validateValueFrom(r *reporter, valueFrom map[string]interface{}, path []interface{}) {
+	var sources []string
+	for _, source := range []string{"fieldRef", "resourceFieldRef", "configMapKeyRef", "secretKeyRef"} {
+		if _, ok := valueFrom[source]; ok {
+			sources = append(sources, source)
+		}
+	}
+	if len(sources) != 1 {
+		r.errorf(RuleEnvSource, path, "valueFrom must define exactly one of fieldRef, resourceFieldRef, configMapKeyRef or secretKeyRef")
+		return
+	}
+
+	source := sources[0]
+	ref, _ := valueFrom[source].(map[string]interface{})
+	switch source {
+	case "fieldRef":
+		fieldPath, _ := ref["fieldPath"].(string)
+		if !envFieldPaths[fieldPath] && !envFieldLabel.MatchString(fieldPath) {
+			r.errorf(RuleEnvSource, subPath(path, source, "fieldPath"), "fieldPath has unsupported value '%s'", fieldPath)
+		}
+	case "resourceFieldRef":
+		resource, _ := ref["resource"].(string)
+		if !envResources[resource] {
+			r.errorf(RuleEnvSource, subPath(path, source, "resource"), "resource has unsupported value '%s'", resource)
+		}
+	default:
+		for _, field := range []string{"name", "key"} {
+			if value, _ := ref[field].(string); value == "" {
+				r.errorf(RuleEnvSource, subPath(path, source, field), "%s.%s is required", source, field)
+			}
+		}
+	}
+}
+
+// Ключи ConfigMap или Secret с данным именем среди документов файла
+func (r *reporter) sourceKeys(kind, name string) []string {
+	var keys []string
+	for _, doc := range r.docs {
+		if doc.Kind() != kind || doc.Name() != name {
+			continue
+		}
+		for _, field := range []string{"data", "binaryData", "stringData"} {
+			values, _ := doc.raw[field].(map[string]interface{})
+			for key := range values {
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Части имён переменных, в которых обычно хранят секреты
+var secretNameParts = []string{"PASSWORD", "PASSWD", "TOKEN", "SECRET", "API_KEY", "APIKEY", "PRIVATE_KEY", "CREDENTIAL"}
+
+// Ссылка на другую переменную вида $(VAR), её значение не секрет
+var envVarRef = regexp.MustCompile(`^\$\([A-Za-z_][A-Za-z0-9_]*\)$`)
+
+// Похоже ли значение переменной на секрет: имя как у секрета или случайная
+// строка с высокой энтропией
+func plaintextSecret(name, value string) bool {
+	if !looksLikeCredential(value) || envVarRef.MatchString(value) {
+		return false
+	}
+	upper := strings.ToUpper(name)
+	for _, part := range secretNameParts {
+		if strings.Contains(upper, part) {
+			return true
+		}
+	}
+	return len(value) >= 20 && !strings.ContainsAny(value, " /") && entropy(value) > 4
+}
+
+// Энтропия Шеннона строки в битах на символ
+func entropy(s string) float64 {
+	counts := make(map[rune]int)
+	total := 0
+	for _, c := range s {
+		counts[c]++
+		total++
+	}
+	var h float64
+	for _, n := range counts {
+		p := float64(n) / float64(total)
+		h -= p * math.Log2(p)
+	}
+	return h
+}
@@ -0,0 +1,28 @@
+package podlint
+
+import "testing"
+
+var envRuleCases = []ruleCase{
+	{rule: RuleEnvReserved, yaml: container("\n      env:\n        - name: APP_MODE\n          value: prod")},
+	{rule: RuleEnvReserved, fail: true, yaml: container("\n      env:\n        - name: WEB_SERVICE_HOST\n          value: localhost")},
+
+	{rule: RuleEnvOverride, yaml: bundle(configData("ConfigMap", "\ndata:\n  MODE: prod"),
+		container("\n      envFrom:\n        - configMapRef: {name: cfg}\n      env:\n        - name: LEVEL\n          value: debug"))},
+	{rule: RuleEnvOverride, fail: true, yaml: bundle(configData("ConfigMap", "\ndata:\n  MODE: prod"),
+		container("\n      envFrom:\n        - configMapRef: {name: cfg}\n      env:\n        - name: MODE\n          value: dev"))},
+
+	{rule: RuleEnvSecret, yaml: container("\n      env:\n        - name: DB_PASSWORD\n          valueFrom:\n            secretKeyRef: {name: db, key: password}")},
+	{rule: RuleEnvSecret, fail: true, yaml: container("\n      env:\n        - name: DB_PASSWORD\n          value: hunter2")},
+
+	{rule: RuleEnvName, yaml: container("\n      env:\n        - name: _MODE\n          value: a")},
+	{rule: RuleEnvName, fail: true, yaml: container("\n      env:\n        - name: 1MODE\n          value: a")},
+	{rule: RuleEnvName, fail: true, yaml: container("\n      env:\n        - name: MODE\n          value: a\n        - name: MODE\n          value: b")},
+
+	{rule: RuleEnvSource, yaml: container("\n      env:\n        - name: POD\n          valueFrom:\n            fieldRef: {fieldPath: \"metadata.labels['app']\"}")},
+	{rule: RuleEnvSource, fail: true, yaml: container("\n      env:\n        - name: POD\n          value: a\n          valueFrom:\n            fieldRef: {fieldPath: metadata.name}")},
+	{rule: RuleEnvSource, fail: true, yaml: container("\n      envFrom:\n        - prefix: APP_")},
+}
+
+func TestEnvRules(t *testing.T) {
+	testRuleCases(t, envRuleCases)
+}
@@ -0,0 +1,64 @@
+package podlint
+
+import "fmt"
+
+// ValidationError — нарушение, найденное при проверке или разборе файла.
+type ValidationError struct {
+	File      string `json:"file"`
+	Document  int    `json:"document"` // номер документа в файле, с нуля
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	FieldPath string `json:"path,omitempty"` // например spec.containers[0].ports[0].containerPort
+	RuleID    string `json:"rule"`
+	Message   string `json:"message"`
+	Severity  string `json:"severity"`
+
+	// Другие места документа, относящиеся к нарушению: первое вхождение
+	// повторяющегося имени, limits при превышении requests и т. п.
+	Related []Location `json:"related,omitempty"`
+
+	// Правило, нарушение которого подавило это (см. RuleDependencies)
+	SuppressedBy string `json:"suppressedBy,omitempty"`
+
+	path []interface{} // путь к полю из ключей и индексов, см. JSONPointer
+}
+
+// Location — связанное с нарушением место в том же документе.
+type Location struct {
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	FieldPath string `json:"path,omitempty"`
+	Message   string `json:"message"`
+}
+
+// Error форматирует нарушение как "<файл>:<строка> <путь>: <сообщение>",
+// опуская неизвестные части.
+func (e *ValidationError) Error() string {
+	msg := e.Message
+	if e.FieldPath != "" {
+		msg = e.FieldPath + ": " + msg
+	}
+	switch {
+	case e.File != "" && e.Line > 0:
+		return fmt.Sprintf("%s:%d %s", e.File, e.Line, msg)
+	case e.File != "":
+		return e.File + ": " + msg
+	default:
+		return msg
+	}
+}
+
+// JSONPointer возвращает путь к полю по RFC 6901, например
+// "/metadata/labels/app.kubernetes.io~1name", или "", если поле не задано.
+func (e *ValidationError) JSONPointer() string {
+	return jsonPointer(e.path)
+}
+
+// Ошибка разбора или ограничений на входные данные (правило RuleInput)
+func inputError(format string, args ...interface{}) *ValidationError {
+	return &ValidationError{
+		RuleID:   RuleInput,
+		Message:  fmt.Sprintf(format, args...),
+		Severity: SeverityError,
+	}
+}
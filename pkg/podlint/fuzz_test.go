@@ -0,0 +1,77 @@
+package podlint
+
+import (
+	"testing"
+)
+
+// Затравка для фаззинга: корректный под, типичные ошибки и пограничный ввод
+var fuzzSeeds = []string{
+	`apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  containers:
+    - name: web
+      image: registry.example.com/web:1.0
+      ports:
+        - containerPort: 8080
+          protocol: TCP
+      resources:
+        limits:
+          cpu: 500m
+          memory: 128Mi
+`,
+	`kind: Pod
+spec:
+  containers:
+    - name: 1
+      image: [x]
+      resources: {limits: {cpu: 1e2000000000}}
+`,
+	"a: &a [*a]\n",
+	"---\n---\nkind: Pod\n...\n",
+	"\xef\xbb\xbfkind: Pod\r\nspec: {}\r\n",
+	"\xff\xfek\x00i\x00n\x00d\x00:\x00 \x00P\x00o\x00d\x00",
+	"[[[[[[[[[[[[[[[[[[[[]]]]]]]]]]]]]]]]]]]]",
+	"kind: Deployment\nspec:\n  template:\n    spec:\n      containers: [{}]\n",
+	":",
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+	opts := DefaultOptions()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		docs, err := Parse(data, opts)
+		if err != nil {
+			if docs != nil {
+				t.Fatalf("Parse returned documents together with error %v", err)
+			}
+			return
+		}
+		for i, doc := range docs {
+			if doc.Index != i || doc.Node == nil {
+				t.Fatalf("document %d: Index=%d Node=%v", i, doc.Index, doc.Node)
+			}
+		}
+	})
+}
+
+func FuzzValidateFile(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+	opts := DefaultOptions()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		res := ValidateFile("fuzz.yaml", data, opts)
+		for _, e := range res.Errors {
+			if e.File != "fuzz.yaml" || e.RuleID == "" {
+				t.Fatalf("malformed finding: %+v", e)
+			}
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package podlint
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// MatchGlob сопоставляет путь с шаблоном, где "**" соответствует любому
+// числу каталогов, а остальные сегменты — как в filepath.Match.
+func MatchGlob(pattern, path string) bool {
+	return matchSegments(strings.Split(filepath.ToSlash(pattern), "/"), strings.Split(filepath.ToSlash(path), "/"))
+}
+
+func matchSegments(pattern, path []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(path); i++ {
+				if matchSegments(pattern[1:], path[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(path) == 0 {
+			return false
+		}
+		if ok, err := filepath.Match(pattern[0], path[0]); err != nil || !ok {
+			return false
+		}
+		pattern, path = pattern[1:], path[1:]
+	}
+	return len(path) == 0
+}
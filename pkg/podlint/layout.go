@@ -0,0 +1,71 @@
+package podlint
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Подстановки шаблона пути, которые сверяются с документом
+var layoutFields = map[string]string{
+	"namespace": "metadata.namespace",
+	"name":      "metadata.name",
+	"kind":      "kind",
+}
+
+// Допустимое имя подстановки
+var placeholderName = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+
+// Регулярное выражение для шаблона пути вида
+// "clusters/<cluster>/<namespace>/<name>.yaml": каждая подстановка —
+// один сегмент пути, шаблон сопоставляется с концом пути файла
+func layoutRegexp(layout string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	expr.WriteString(`(?:^|/)`)
+	rest := layout
+	for {
+		start := strings.Index(rest, "<")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(rest[start:], ">")
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed '<' in layout '%s'", layout)
+		}
+		name := rest[start+1 : start+end]
+		if !placeholderName.MatchString(name) {
+			return nil, fmt.Errorf("invalid placeholder '<%s>' in layout '%s'", name, layout)
+		}
+		expr.WriteString(regexp.QuoteMeta(rest[:start]))
+		expr.WriteString(`(?P<` + name + `>[^/]+)`)
+		rest = rest[start+end+1:]
+	}
+	expr.WriteString(regexp.QuoteMeta(rest) + `$`)
+	return regexp.Compile(expr.String())
+}
+
+// Проверка, что namespace, name и kind документа совпадают с частями пути файла
+func validateLayout(r *reporter, file string, doc *Document, layout string) {
+	re, err := layoutRegexp(layout)
+	if err != nil {
+		return
+	}
+	match := re.FindStringSubmatch(filepath.ToSlash(filepath.Clean(file)))
+	if match == nil {
+		r.errorf(RulePathLayout, nil, "file path does not match layout '%s'", layout)
+		return
+	}
+	values := map[string]string{"namespace": doc.Namespace(), "name": doc.Name(), "kind": doc.Kind()}
+	for i, placeholder := range re.SubexpNames() {
+		field, ok := layoutFields[placeholder]
+		if !ok || strings.EqualFold(values[placeholder], match[i]) {
+			continue
+		}
+		path := make([]interface{}, 0, 2)
+		for _, key := range strings.Split(field, ".") {
+			path = append(path, key)
+		}
+		r.errorf(RulePathLayout, path, "%s '%s' does not match '%s' from the file path", field, values[placeholder], match[i])
+	}
+}
@@ -0,0 +1,31 @@
+package podlint
+
+import "testing"
+
+var layoutRuleCases = []ruleCase{
+	{rule: RulePathLayout, file: "clusters/eu/web.yaml", yaml: validPod,
+		opts: func(o *Options) { o.PathLayout = "<cluster>/<name>.yaml" }},
+	{rule: RulePathLayout, fail: true, file: "clusters/eu/api.yaml", yaml: validPod,
+		opts: func(o *Options) { o.PathLayout = "<cluster>/<name>.yaml" }},
+	// У фрагмента нет metadata, раскладка не проверяется
+	{rule: RulePathLayout, file: "clusters/eu/api.yaml", yaml: "name: web\nimage: registry.example.com/web:1.2.3\n",
+		opts: func(o *Options) { o.PathLayout = "<cluster>/<name>.yaml"; o.Fragment = FragmentContainer }},
+}
+
+func TestLayoutRules(t *testing.T) {
+	testRuleCases(t, layoutRuleCases)
+}
+
+func TestLayoutInactiveForFragments(t *testing.T) {
+	opts := DefaultOptions()
+	opts.PathLayout = "<cluster>/<name>.yaml"
+	if !ActiveRules(opts)[RulePathLayout] {
+		t.Errorf("%s inactive with a path layout", RulePathLayout)
+	}
+	for _, fragment := range []string{FragmentContainer, FragmentPodSpec} {
+		opts.Fragment = fragment
+		if ActiveRules(opts)[RulePathLayout] {
+			t.Errorf("%s: %s active for a fragment", fragment, RulePathLayout)
+		}
+	}
+}
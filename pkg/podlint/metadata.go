@@ -0,0 +1,126 @@
+package podlint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Имена по RFC 1123: метка — строчные буквы, цифры и "-", поддомен — метки
+// через точку
+var (
+	dnsLabel     = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	dnsSubdomain = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// Имя в ключе метки и значение метки: буквы, цифры, "-", "_" и "." с
+// буквой или цифрой по краям
+var qualifiedName = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+
+// Ограничения длины Kubernetes
+const (
+	maxLabelLength     = 63
+	maxSubdomainLength = 253
+	maxAnnotationsSize = 256 << 10 // суммарно ключи и значения всех аннотаций
+)
+
+// Проверка metadata: name и namespace по RFC 1123, метки, аннотации и
+// обязательные аннотации из opts
+func validateMetadata(r *reporter, metadata map[string]interface{}, path []interface{}, opts Options) {
+	// --- metadata.name ---
+	if name, ok := metadata["name"].(string); ok && name != "" {
+		if reason := subdomainError(name); reason != "" {
+			r.errorf(RuleDNSName, subPath(path, "name"), "name '%s' %s", name, reason)
+		}
+	}
+
+	// --- metadata.namespace ---
+	if namespace, ok := metadata["namespace"].(string); ok && namespace != "" {
+		if len(namespace) > maxLabelLength {
+			r.errorf(RuleDNSName, subPath(path, "namespace"), "namespace '%s' is longer than %d characters", namespace, maxLabelLength)
+		} else if !dnsLabel.MatchString(namespace) {
+			r.errorf(RuleDNSName, subPath(path, "namespace"), "namespace '%s' must be a DNS-1123 label", namespace)
+		}
+	}
+
+	validateLabels(r, metadata, path)
+	validateAnnotations(r, metadata, path)
+
+	// --- обязательные аннотации ---
+	annotations, _ := metadata["annotations"].(map[string]interface{})
+	for _, key := range opts.RequiredAnnotations {
+		if value, ok := annotations[key]; !ok || value == nil || value == "" {
+			r.errorf(RuleAnnotationReq, subPath(path, "annotations"), "annotation '%s' is required", key)
+		}
+	}
+}
+
+// Проверка metadata.labels: ключ — [префикс/]имя, значение — пустое или имя
+func validateLabels(r *reporter, metadata map[string]interface{}, path []interface{}) {
+	labels, _ := metadata["labels"].(map[string]interface{})
+	for _, key := range sortedKeys(labels) {
+		if reason := labelKeyError(key); reason != "" {
+			r.keyErrorf(RuleLabelFormat, subPath(path, "labels", key), "label key '%s' %s", key, reason)
+		}
+		value := fmt.Sprint(labels[key])
+		if labels[key] == nil {
+			value = ""
+		}
+		switch {
+		case len(value) > maxLabelLength:
+			r.errorf(RuleLabelFormat, subPath(path, "labels", key), "label '%s' value is longer than %d characters", key, maxLabelLength)
+		case value != "" && !qualifiedName.MatchString(value):
+			r.errorf(RuleLabelFormat, subPath(path, "labels", key), "label '%s' has invalid value '%s'", key, value)
+		}
+	}
+}
+
+// Проверка metadata.annotations: ключи как у меток и общий размер
+func validateAnnotations(r *reporter, metadata map[string]interface{}, path []interface{}) {
+	annotations, _ := metadata["annotations"].(map[string]interface{})
+	size := 0
+	for _, key := range sortedKeys(annotations) {
+		if reason := labelKeyError(key); reason != "" {
+			r.keyErrorf(RuleAnnotation, subPath(path, "annotations", key), "annotation key '%s' %s", key, reason)
+		}
+		size += len(key)
+		if value := annotations[key]; value != nil {
+			size += len(fmt.Sprint(value))
+		}
+	}
+	if size > maxAnnotationsSize {
+		r.errorf(RuleAnnotation, subPath(path, "annotations"), "annotations size %d exceeds limit of %d bytes", size, maxAnnotationsSize)
+	}
+}
+
+// Почему s не поддомен DNS-1123; "" — подходит
+func subdomainError(s string) string {
+	if len(s) > maxSubdomainLength {
+		return fmt.Sprintf("is longer than %d characters", maxSubdomainLength)
+	}
+	if !dnsSubdomain.MatchString(s) {
+		return "must be a DNS-1123 subdomain: lowercase letters, digits, '-' and '.'"
+	}
+	return ""
+}
+
+// Почему key не подходит как ключ метки или аннотации; "" — подходит
+func labelKeyError(key string) string {
+	name := key
+	if prefix, rest, ok := strings.Cut(key, "/"); ok {
+		if prefix == "" {
+			return "has an empty prefix"
+		}
+		if reason := subdomainError(prefix); reason != "" {
+			return "prefix " + reason
+		}
+		name = rest
+	}
+	if len(name) > maxLabelLength {
+		return fmt.Sprintf("name is longer than %d characters", maxLabelLength)
+	}
+	if !qualifiedName.MatchString(name) {
+		return "must be a qualified name: letters, digits, '-', '_' and '.', starting and ending with a letter or digit"
+	}
+	return ""
+}
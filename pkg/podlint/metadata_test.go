@@ -0,0 +1,25 @@
+package podlint
+
+import "testing"
+
+var metadataRuleCases = []ruleCase{
+	{rule: RuleDNSName, yaml: podMeta("\n  name: web.example\n  namespace: prod-1", "\n  containers: []")},
+	{rule: RuleDNSName, fail: true, yaml: podMeta("\n  name: Web_1", "\n  containers: []")},
+	{rule: RuleDNSName, fail: true, yaml: podMeta("\n  name: web\n  namespace: prod.eu", "\n  containers: []")},
+
+	{rule: RuleLabelFormat, yaml: podMeta("\n  name: web\n  labels:\n    app.kubernetes.io/name: web\n    empty: \"\"", "\n  containers: []")},
+	{rule: RuleLabelFormat, fail: true, yaml: podMeta("\n  name: web\n  labels:\n    app: -web-", "\n  containers: []")},
+	{rule: RuleLabelFormat, fail: true, yaml: podMeta("\n  name: web\n  labels:\n    /app: web", "\n  containers: []")},
+
+	{rule: RuleAnnotation, yaml: podMeta("\n  name: web\n  annotations:\n    example.com/team: \"payments / core\"", "\n  containers: []")},
+	{rule: RuleAnnotation, fail: true, yaml: podMeta("\n  name: web\n  annotations:\n    Example.com/team: core", "\n  containers: []")},
+
+	{rule: RuleAnnotationReq, yaml: podMeta("\n  name: web\n  annotations:\n    team: core", "\n  containers: []"),
+		opts: func(o *Options) { o.RequiredAnnotations = []string{"team"} }},
+	{rule: RuleAnnotationReq, fail: true, yaml: validPod,
+		opts: func(o *Options) { o.RequiredAnnotations = []string{"team"} }},
+}
+
+func TestMetadataRules(t *testing.T) {
+	testRuleCases(t, metadataRuleCases)
+}
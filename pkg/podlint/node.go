@@ -0,0 +1,111 @@
+package podlint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Позиция поля по пути из ключей (string) и индексов (int): строка и
+// колонка ключа или элемента списка, а для скалярного значения — колонка
+// самого значения. Для отсутствующего поля возвращается позиция ближайшего
+// существующего предка.
+func nodePos(n *yaml.Node, path []interface{}) (line, column int) {
+	return position(n, path, true)
+}
+
+// Позиция ключа последнего элемента path, даже если его значение скалярное:
+// для нарушений в имени ключа, а не в значении
+func keyPos(n *yaml.Node, path []interface{}) (line, column int) {
+	return position(n, path, false)
+}
+
+func position(n *yaml.Node, path []interface{}, value bool) (line, column int) {
+	if n == nil {
+		return 0, 0
+	}
+	if n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		n = n.Content[0]
+	}
+	line, column = n.Line, n.Column
+	for _, p := range path {
+		var key, next *yaml.Node
+		switch k := p.(type) {
+		case string:
+			if n.Kind == yaml.MappingNode {
+				for i := 0; i+1 < len(n.Content); i += 2 {
+					if n.Content[i].Value == k {
+						key, next = n.Content[i], n.Content[i+1]
+						break
+					}
+				}
+			}
+		case int:
+			if n.Kind == yaml.SequenceNode && k < len(n.Content) {
+				key, next = n.Content[k], n.Content[k]
+			}
+		}
+		if next == nil {
+			return line, column
+		}
+		line, column = key.Line, key.Column
+		if value && next.Kind == yaml.ScalarNode {
+			line, column = next.Line, next.Column
+		}
+		n = next
+	}
+	return line, column
+}
+
+// Глубина вложенности дерева узлов (алиасы не раскрываются)
+func nodeDepth(n *yaml.Node) int {
+	depth := 0
+	for _, c := range n.Content {
+		if d := nodeDepth(c); d > depth {
+			depth = d
+		}
+	}
+	return depth + 1
+}
+
+// Путь к полю в виде "spec.containers[0].ports[1].containerPort"
+func fieldPath(path []interface{}) string {
+	var b strings.Builder
+	for _, p := range path {
+		switch key := p.(type) {
+		case string:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(key)
+		case int:
+			fmt.Fprintf(&b, "[%d]", key)
+		}
+	}
+	return b.String()
+}
+
+// Путь к полю в виде JSON Pointer "/spec/containers/0/name"; "~" и "/" в
+// ключах экранируются как "~0" и "~1"
+func jsonPointer(path []interface{}) string {
+	var b strings.Builder
+	for _, p := range path {
+		b.WriteByte('/')
+		switch key := p.(type) {
+		case string:
+			b.WriteString(pointerEscaper.Replace(key))
+		case int:
+			b.WriteString(strconv.Itoa(key))
+		}
+	}
+	return b.String()
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Путь к дочернему узлу; исходный срез не изменяется
+func subPath(path []interface{}, elems ...interface{}) []interface{} {
+	return append(append([]interface{}{}, path...), elems...)
+}
@@ -0,0 +1,19 @@
+package podlint
+
+import "testing"
+
+func TestValidateFilePositions(t *testing.T) {
+	data := bundle(validPod, container("\n      ports:\n        - containerPort: 0"))
+	errs := ValidateBytes("pod.yaml", []byte(data), DefaultOptions())
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 finding, got %v", errs)
+	}
+	e := errs[0]
+	want := ValidationError{File: "pod.yaml", Document: 1, Line: 32, Column: 26,
+		FieldPath: "spec.containers[0].ports[0].containerPort", RuleID: RulePortRange,
+		Message: "containerPort value out of range", Severity: SeverityError}
+	if e.File != want.File || e.Document != want.Document || e.Line != want.Line || e.Column != want.Column ||
+		e.FieldPath != want.FieldPath || e.RuleID != want.RuleID || e.Message != want.Message || e.Severity != want.Severity {
+		t.Errorf("got %+v, want %+v", e, want)
+	}
+}
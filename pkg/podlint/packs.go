@@ -0,0 +1,85 @@
+package podlint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// PolicyPack — настройки правил для документов из пространств имён, метки
+// которых содержат все пары Selector.
+type PolicyPack struct {
+	Name          string
+	Selector      map[string]string
+	DisabledRules map[string]bool
+	Severities    map[string]string
+}
+
+// Подходят ли метки пространства имён под селектор набора
+func (p PolicyPack) matches(labels map[string]string) bool {
+	if len(p.Selector) == 0 || labels == nil {
+		return false
+	}
+	for key, value := range p.Selector {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Метки пространства имён документа: из манифеста Namespace в том же файле,
+// а если его нет — из opts.NamespaceLabels. nil, если пространство неизвестно.
+func namespaceLabels(doc *Document, docs []*Document, opts Options) map[string]string {
+	namespace := doc.Namespace()
+	if namespace == "" {
+		return nil
+	}
+	for _, d := range docs {
+		if d.Kind() == "Namespace" && d.Name() == namespace {
+			return stringLabels(nested(d.raw, "metadata", "labels"))
+		}
+	}
+	return opts.NamespaceLabels[namespace]
+}
+
+// Метки в виде строк; нестроковые значения приводятся через fmt.Sprint
+func stringLabels(m map[string]interface{}) map[string]string {
+	labels := make(map[string]string, len(m))
+	for key, value := range m {
+		labels[key] = fmt.Sprint(value)
+	}
+	return labels
+}
+
+// LoadNamespaces читает манифесты Namespace из файлов, подходящих под шаблоны
+// filepath.Glob, и возвращает метки по именам пространств имён.
+// Документы других kind пропускаются.
+func LoadNamespaces(patterns []string) (map[string]map[string]string, error) {
+	namespaces := make(map[string]map[string]string)
+	for _, pattern := range patterns {
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pattern, err)
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("%s: no files match", pattern)
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			docs, err := Parse(data, DefaultOptions())
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", file, err)
+			}
+			for _, doc := range docs {
+				if doc.Kind() == "Namespace" && doc.Name() != "" {
+					namespaces[doc.Name()] = stringLabels(nested(doc.raw, "metadata", "labels"))
+				}
+			}
+		}
+	}
+	return namespaces, nil
+}
@@ -0,0 +1,54 @@
+package podlint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfigPolicyPacks(t *testing.T) {
+	opts := configOptions(t, `
+policyPacks:
+  - name: sandbox
+    namespaceLabels: {tier: sandbox}
+    rules:
+      IMG002: {disabled: true}
+  - name: regulated
+    namespaceLabels: {tier: regulated}
+    rules:
+      SEC002: {disabled: false, severity: error}
+`)
+	namespace := func(name, tier string) string {
+		return "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: " + name + "\n  labels: {tier: " + tier + "}\n"
+	}
+	inNamespace := func(ns string) string {
+		return strings.Replace(untaggedPod, "namespace: prod", "namespace: "+ns, 1)
+	}
+
+	tests := []struct {
+		name   string
+		yaml   string
+		labels map[string]map[string]string
+		want   map[string]string // уровни IMG002 и SEC002
+	}{
+		{"no pack", untaggedPod, nil,
+			map[string]string{RuleImageTag: SeverityError}},
+		{"namespace manifest", bundle(namespace("dev", "sandbox"), inNamespace("dev")), nil,
+			map[string]string{}},
+		{"namespace labels", inNamespace("dev"), map[string]map[string]string{"dev": {"tier": "sandbox"}},
+			map[string]string{}},
+		{"regulated", bundle(namespace("bank", "regulated"), inNamespace("bank")), nil,
+			map[string]string{RuleImageTag: SeverityError, RuleRunAsNonRoot: SeverityError}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := opts
+			opts.NamespaceLabels = tt.labels
+			got := ruleSeverities(ValidateBytes("pod.yaml", []byte(tt.yaml), opts))
+			for _, rule := range []string{RuleImageTag, RuleRunAsNonRoot} {
+				if got[rule] != tt.want[rule] {
+					t.Errorf("%s: severity %q, want %q", rule, got[rule], tt.want[rule])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+// Package podlint проверяет манифесты Kubernetes Pod в YAML.
+//
+// Parse разбирает файл на документы с учётом ограничений на входные данные,
+// Validate проверяет документ и возвращает найденные нарушения. ValidateBytes
+// объединяет оба шага и превращает ошибки разбора в нарушения RuleInput.
+package podlint
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+
+	"main.go/pkg/quantity"
+)
+
+// Виды фрагментов для Options.Fragment
+const (
+	FragmentContainer = "container"
+	FragmentPodSpec   = "podspec"
+)
+
+// Options — параметры проверки.
+type Options struct {
+	RequireProtocol bool // protocol обязателен для каждого порта
+
+	// Ограничения на входные данные, 0 — без ограничения
+	MaxFileSize  int64
+	MaxDepth     int
+	MaxDocuments int
+
+	// Проверка фрагмента вместо Pod: FragmentContainer или FragmentPodSpec
+	Fragment string
+
+	// Селектор меток критичных подов, для которых нужна anti-affinity или topology spread
+	HASelector string
+
+	// Разрешённые префиксы образов, пустой список — любой реестр
+	Registries []string
+
+	// Формат имени контейнера, nil — без проверки
+	ContainerName *regexp.Regexp
+
+	// Политика тегов образов: запрет latest, теги вида семантической версии,
+	// обязательное закрепление по digest @sha256
+	DenyLatest    bool
+	SemverTags    bool
+	RequireDigest bool
+
+	// Формат тега образа для выбранного окружения, nil — без проверки.
+	// Образы, закреплённые только по digest, подходят всегда.
+	ImageTag *regexp.Regexp
+
+	// Поля через точку (metadata.namespace), обязательные в каждом Pod
+	RequiredFields []string
+
+	// Аннотации, обязательные в metadata каждого документа, например team
+	RequiredAnnotations []string
+
+	// Допустимые ключи resources.limits/requests, пустой список — любые.
+	// Расширенные ресурсы с доменом (example.com/gpu) разрешены всегда.
+	ResourceKeys []string
+
+	// Отключённые правила
+	DisabledRules map[string]bool
+
+	// Уровень серьёзности по правилам, по умолчанию SeverityError
+	Severities map[string]string
+
+	// Настройки правил для отдельных файлов, kind и пространств имён,
+	// применяются по порядку поверх DisabledRules и Severities
+	Overrides []RuleOverride
+
+	// Наборы правил для пространств имён с подходящими метками, например
+	// tier=regulated; применяются по порядку до Overrides. Метки берутся из
+	// NamespaceLabels и из манифестов Namespace в проверяемом файле.
+	Packs           []PolicyPack
+	NamespaceLabels map[string]map[string]string
+
+	// Документы этих kind не проверяются
+	SkipKinds []string
+
+	// Файлы, пути которых подходят под шаблоны, не проверяются (см. IgnoredFile)
+	IgnoreFilenames []*regexp.Regexp
+
+	// Версии кластеров, на которые рассчитаны манифесты; nil — без проверки
+	KubernetesVersions *VersionRange
+
+	// Шаблон пути файла вида "clusters/<cluster>/<namespace>/<name>.yaml",
+	// с которым сверяются metadata.namespace, metadata.name и kind; "" — без проверки
+	PathLayout string
+
+	// Бюджеты, превышение которых — предупреждение; 0 — без ограничения.
+	// Суммарные cpu и memory пода считаются по limits, а без них — по requests.
+	MaxContainers   int
+	MaxPodCPU       quantity.Quantity
+	MaxPodMemory    quantity.Quantity
+	MaxManifestSize int64
+}
+
+// RuleOverride — настройки правил для документов, которые подходят под все
+// заданные условия; пустое условие подходит под любой документ.
+type RuleOverride struct {
+	Files      []string // шаблоны путей, как в MatchGlob
+	Kinds      []string
+	Namespaces []string
+
+	DisabledRules map[string]bool
+	Severities    map[string]string
+}
+
+// Действует ли переопределение на все документы
+func (o RuleOverride) unconditional() bool {
+	return len(o.Files) == 0 && len(o.Kinds) == 0 && len(o.Namespaces) == 0
+}
+
+// Подходит ли документ doc из файла file под условия
+func (o RuleOverride) matches(file string, doc *Document) bool {
+	if len(o.Files) > 0 {
+		matched := false
+		for _, pattern := range o.Files {
+			if file != "" && MatchGlob(pattern, filepath.Clean(file)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return contains(o.Kinds, doc.Kind()) && contains(o.Namespaces, doc.Namespace())
+}
+
+// Есть ли value в list; пустой list разрешает любое значение
+func contains(list []string, value string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// SkipsKind сообщает, исключены ли документы этого kind параметром SkipKinds.
+func (o Options) SkipsKind(kind string) bool {
+	for _, skip := range o.SkipKinds {
+		if kind == skip {
+			return true
+		}
+	}
+	return false
+}
+
+// IgnoredFile сообщает, исключён ли файл шаблонами IgnoreFilenames.
+func (o Options) IgnoredFile(name string) bool {
+	for _, re := range o.IgnoreFilenames {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultOptions возвращает параметры по умолчанию, как у CLI.
+func DefaultOptions() Options {
+	return Options{
+		MaxFileSize:  10 << 20,
+		MaxDepth:     100,
+		MaxDocuments: 1000,
+		HASelector:   "tier=critical",
+		// Имя контейнера — метка DNS-1123, как требует Kubernetes; реестры,
+		// своё соглашение об именовании и ключи ресурсов задаёт конфигурация
+		ContainerName: dnsLabel,
+		// Строгие правила securityContext включаются в конфигурации
+		DisabledRules: map[string]bool{RuleRunAsNonRoot: true, RuleEscalation: true},
+	}
+}
+
+// Document — один YAML-документ из файла.
+type Document struct {
+	Index int        // номер документа в файле, с нуля
+	Node  *yaml.Node // дерево узлов, по нему определяются позиции нарушений
+
+	raw map[string]interface{}
+}
+
+// Kind возвращает kind документа или "", если он не задан.
+func (d *Document) Kind() string {
+	kind, _ := d.raw["kind"].(string)
+	return kind
+}
+
+// APIVersion возвращает apiVersion документа или "".
+func (d *Document) APIVersion() string {
+	version, _ := d.raw["apiVersion"].(string)
+	return version
+}
+
+// Name возвращает metadata.name документа или "".
+func (d *Document) Name() string {
+	metadata, _ := d.raw["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	return name
+}
+
+// Namespace возвращает metadata.namespace документа или "".
+func (d *Document) Namespace() string {
+	metadata, _ := d.raw["metadata"].(map[string]interface{})
+	namespace, _ := metadata["namespace"].(string)
+	return namespace
+}
+
+// FileResult — результат проверки одного файла.
+type FileResult struct {
+	Name      string
+	Encoding  string      // исходная кодировка, см. Normalize
+	Documents []*Document // nil, если файл не удалось разобрать
+	Errors    []ValidationError
+
+	// Нарушения, не попавшие в Errors из-за нарушения правила-первопричины
+	Suppressed []ValidationError
+
+	// Отключённые правила каждого проверенного документа с учётом Packs и
+	// Overrides; документы пропущенных kind не входят
+	Disabled []map[string]bool
+}
+
+// Parse разбирает data на документы. Ошибки синтаксиса и превышение
+// ограничений из opts возвращаются как *ValidationError с RuleInput.
+func Parse(data []byte, opts Options) ([]*Document, error) {
+	if opts.MaxFileSize > 0 && int64(len(data)) > opts.MaxFileSize {
+		return nil, inputError("file size exceeds limit of %d bytes", opts.MaxFileSize)
+	}
+
+	var docs []*Document
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var node yaml.Node
+		if err := dec.Decode(&node); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, inputError("YAML decode error: %v", err)
+		}
+		if opts.MaxDocuments > 0 && len(docs) >= opts.MaxDocuments {
+			return nil, inputError("number of documents exceeds limit of %d", opts.MaxDocuments)
+		}
+		if opts.MaxDepth > 0 && nodeDepth(&node) > opts.MaxDepth {
+			return nil, inputError("nesting depth exceeds limit of %d", opts.MaxDepth)
+		}
+
+		doc := &Document{Index: len(docs), Node: &node}
+		if err := node.Decode(&doc.raw); err != nil {
+			return nil, inputError("YAML decode error: %v", err)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// Validate проверяет документ и возвращает нарушения без имени файла.
+// Нарушения, подавленные первопричиной, не возвращаются.
+func Validate(doc *Document, opts Options) []ValidationError {
+	findings, _ := validate("", doc, nil, opts)
+	return findings
+}
+
+// Проверка документа из файла file; docs — все документы файла, в них
+// ищутся ConfigMap, Secret и поды, на которые ссылается проверяемый документ.
+// Возвращает нарушения и подавленные первопричиной нарушения.
+func validate(file string, doc *Document, docs []*Document, opts Options) (findings, suppressed []ValidationError) {
+	if opts.SkipsKind(doc.Kind()) {
+		return nil, nil
+	}
+
+	r := newReporter(file, doc, docs, opts)
+	validatePod(r, doc.raw, opts)
+	if opts.KubernetesVersions != nil && opts.Fragment == "" {
+		validateVersions(r, doc, *opts.KubernetesVersions)
+	}
+	if opts.PathLayout != "" && opts.Fragment == "" && file != "" {
+		validateLayout(r, file, doc, opts.PathLayout)
+	}
+	r.suppress()
+	return r.findings, r.suppressed
+}
+
+// ValidateFile разбирает и проверяет содержимое файла name, все документы по
+// очереди; кодировка и переводы строк сначала приводятся Normalize. Номер
+// документа записывается в ValidationError.Document. Ошибка декодирования
+// или разбора возвращается единственным нарушением RuleInput. MaxFileSize
+// ограничивает исходный размер, до нормализации.
+func ValidateFile(name string, data []byte, opts Options) FileResult {
+	res := FileResult{Name: name}
+	size := len(data)
+	var docs []*Document
+	var err error
+	if opts.MaxFileSize > 0 && int64(size) > opts.MaxFileSize {
+		err = inputError("file size exceeds limit of %d bytes", opts.MaxFileSize)
+	} else {
+		data, res.Encoding, err = Normalize(data)
+	}
+	if err == nil {
+		docs, err = Parse(data, opts)
+	}
+	if err != nil {
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			verr = inputError("%v", err)
+		}
+		failure := *verr
+		failure.File = name
+		res.Errors = []ValidationError{failure}
+		return res
+	}
+	res.Documents = docs
+	for _, doc := range docs {
+		if !opts.SkipsKind(doc.Kind()) {
+			disabled, _ := documentRules(name, doc, docs, opts)
+			res.Disabled = append(res.Disabled, disabled)
+		}
+		findings, suppressed := validate(name, doc, docs, opts)
+		res.Errors = append(res.Errors, findings...)
+		res.Suppressed = append(res.Suppressed, suppressed...)
+	}
+	if opts.MaxManifestSize > 0 && int64(size) > opts.MaxManifestSize && len(docs) > 0 {
+		r := newReporter(name, docs[0], docs, opts)
+		r.errorf(RuleManifestSize, nil, "manifest size %d exceeds budget of %d bytes", size, opts.MaxManifestSize)
+		res.Errors = append(res.Errors, r.findings...)
+	}
+	for i := range res.Errors {
+		res.Errors[i].File = name
+	}
+	for i := range res.Suppressed {
+		res.Suppressed[i].File = name
+	}
+	return res
+}
+
+// ValidateBytes — то же, что ValidateFile, но возвращает только нарушения.
+func ValidateBytes(name string, data []byte, opts Options) []ValidationError {
+	return ValidateFile(name, data, opts).Errors
+}
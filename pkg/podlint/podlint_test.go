@@ -0,0 +1,240 @@
+package podlint
+
+import (
+	"strings"
+	"testing"
+)
+
+// Pod с метаданными meta и spec; оба фрагмента начинаются с перевода строки
+// и записаны с отступом своего уровня
+func podMeta(meta, spec string) string {
+	return "apiVersion: v1\nkind: Pod\nmetadata:" + meta + "\nspec:" + spec + "\n"
+}
+
+// Pod web со spec
+func pod(spec string) string {
+	return podMeta("\n  name: web\n  labels:\n    app: web", spec)
+}
+
+// Pod с одним контейнером web и дополнительными полями контейнера fields
+func container(fields string) string {
+	return pod(`
+  containers:
+    - name: web
+      image: registry.example.com/web:1.2.3` + fields)
+}
+
+// Pod, на котором не срабатывает ни одно правило при DefaultOptions
+var validPod = container(`
+      ports:
+        - containerPort: 8080
+          protocol: TCP
+      resources:
+        requests: {cpu: 100m, memory: 64Mi}
+        limits: {cpu: 500m, memory: 128Mi}
+      securityContext:
+        runAsNonRoot: true
+        allowPrivilegeEscalation: false`)
+
+// Контроллер kind со spec
+func workload(apiVersion, kind, spec string) string {
+	return "apiVersion: " + apiVersion + "\nkind: " + kind + "\nmetadata:\n  name: web\nspec:" + spec + "\n"
+}
+
+// Шаблон пода для контроллеров с отступом spec
+const template = `
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+        - name: web
+          image: registry.example.com/web:1.2.3`
+
+// Service web со spec
+func service(spec string) string {
+	return workload("v1", "Service", spec)
+}
+
+// Секрет или ConfigMap с телом body
+func configData(kind, body string) string {
+	return "apiVersion: v1\nkind: " + kind + "\nmetadata:\n  name: cfg" + body + "\n"
+}
+
+func bundle(docs ...string) string {
+	return strings.Join(docs, "---\n")
+}
+
+type ruleCase struct {
+	rule string
+	fail bool   // ожидается нарушение rule
+	yaml string // содержимое файла
+	file string // имя файла, по умолчанию pod.yaml
+	opts func(*Options)
+}
+
+var ruleCases = []ruleCase{
+	{rule: RuleInput, yaml: validPod},
+	{rule: RuleInput, fail: true, yaml: "kind: [Pod\n"},
+
+	{rule: RuleNameRequired, yaml: validPod},
+	{rule: RuleNameRequired, fail: true, yaml: podMeta("\n  labels: {app: web}", "\n  containers: []")},
+
+	{rule: RuleOSUnsupported, yaml: pod("\n  os: linux\n  containers: []")},
+	{rule: RuleOSUnsupported, fail: true, yaml: pod("\n  os: macos\n  containers: []")},
+
+	{rule: RuleHAPlacement, yaml: podMeta("\n  name: web\n  labels: {tier: critical}",
+		"\n  topologySpreadConstraints:\n    - maxSkew: 1\n      topologyKey: zone\n  containers: []")},
+	{rule: RuleHAPlacement, fail: true, yaml: podMeta("\n  name: web\n  labels: {tier: critical}", "\n  containers: []")},
+
+	{rule: RuleContainerName, yaml: validPod},
+	{rule: RuleContainerName, fail: true, yaml: pod("\n  containers:\n    - image: web:1")},
+
+	{rule: RulePortRange, yaml: validPod},
+	{rule: RulePortRange, fail: true, yaml: container("\n      ports:\n        - containerPort: 70000")},
+
+	{rule: RulePortProtocol, yaml: validPod,
+		opts: func(o *Options) { o.RequireProtocol = true }},
+	{rule: RulePortProtocol, yaml: container("\n      ports:\n        - containerPort: 8080")},
+	{rule: RulePortProtocol, fail: true, yaml: container("\n      ports:\n        - containerPort: 8080"),
+		opts: func(o *Options) { o.RequireProtocol = true }},
+
+	{rule: RuleProbePort, yaml: container("\n      readinessProbe:\n        httpGet: {path: /, port: 8080}")},
+	{rule: RuleProbePort, fail: true, yaml: container("\n      readinessProbe:\n        tcpSocket: {port: 0}")},
+}
+
+// Сообщения нарушений правила rule
+func findingsOf(errs []ValidationError, rule string) []string {
+	var messages []string
+	for _, e := range errs {
+		if e.RuleID == rule {
+			messages = append(messages, e.Error())
+		}
+	}
+	return messages
+}
+
+func TestRules(t *testing.T) {
+	testRuleCases(t, ruleCases)
+}
+
+// Проверяет каждый случай из cases
+func testRuleCases(t *testing.T, cases []ruleCase) {
+	t.Helper()
+	for _, tc := range cases {
+		name := tc.rule + "/pass"
+		if tc.fail {
+			name = tc.rule + "/fail"
+		}
+		t.Run(name, func(t *testing.T) {
+			opts := DefaultOptions()
+			if tc.opts != nil {
+				tc.opts(&opts)
+			}
+			file := tc.file
+			if file == "" {
+				file = "pod.yaml"
+			}
+			res := ValidateFile(file, []byte(tc.yaml), opts)
+			found := findingsOf(res.Errors, tc.rule)
+			switch {
+			case tc.fail && len(found) == 0:
+				t.Errorf("expected %s, got %v", tc.rule, res.Errors)
+			case !tc.fail && len(found) > 0:
+				t.Errorf("unexpected %s: %v", tc.rule, found)
+			}
+		})
+	}
+}
+
+// Таблицы случаев всех правил
+var ruleCaseTables = [][]ruleCase{
+	ruleCases, checkRuleCases, metadataRuleCases, workloadRuleCases, serviceRuleCases, configDataRuleCases,
+	budgetRuleCases, layoutRuleCases, versionRuleCases, volumeRuleCases, securityRuleCases, duplicateRuleCases,
+	probeRuleCases, commandRuleCases, envRuleCases,
+}
+
+// Для каждого правила есть случай без нарушения и с нарушением
+func TestRuleCasesCoverAllRules(t *testing.T) {
+	pass, fail := make(map[string]bool), make(map[string]bool)
+	for _, cases := range ruleCaseTables {
+		for _, tc := range cases {
+			if tc.fail {
+				fail[tc.rule] = true
+			} else {
+				pass[tc.rule] = true
+			}
+		}
+	}
+	for _, ri := range Rules {
+		if !pass[ri.ID] {
+			t.Errorf("%s: no passing case", ri.ID)
+		}
+		if !fail[ri.ID] {
+			t.Errorf("%s: no failing case", ri.ID)
+		}
+	}
+}
+
+func TestValidPod(t *testing.T) {
+	if errs := ValidateBytes("pod.yaml", []byte(validPod), DefaultOptions()); len(errs) > 0 {
+		t.Errorf("unexpected findings: %v", errs)
+	}
+}
+
+// Нарушение в значении указывает на значение, в имени ключа — на ключ
+func TestKeyAndValuePositions(t *testing.T) {
+	data := podMeta("\n  name: web\n  labels:\n    app: -web-\n    /app: web", "\n  containers: []")
+	errs := findingsOfRule(ValidateBytes("pod.yaml", []byte(data), DefaultOptions()), RuleLabelFormat)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 %s findings, got %v", RuleLabelFormat, errs)
+	}
+	for _, e := range errs {
+		want := 10 // значение -web-
+		if e.FieldPath == "metadata.labels./app" {
+			want = 5 // ключ /app
+		}
+		if e.Column != want {
+			t.Errorf("%s: got column %d, want %d", e.FieldPath, e.Column, want)
+		}
+	}
+}
+
+func TestJSONPointer(t *testing.T) {
+	tests := []struct {
+		yaml string
+		want string
+	}{
+		{podMeta("\n  name: web\n  labels:\n    app.kubernetes.io/name: -web-", "\n  containers: []"),
+			"/metadata/labels/app.kubernetes.io~1name"},
+		{podMeta("\n  name: web\n  labels:\n    example.com/a~b: web", "\n  containers: []"),
+			"/metadata/labels/example.com~1a~0b"},
+	}
+	for _, tt := range tests {
+		errs := findingsOfRule(ValidateBytes("pod.yaml", []byte(tt.yaml), DefaultOptions()), RuleLabelFormat)
+		if len(errs) != 1 {
+			t.Fatalf("expected 1 %s finding, got %v", RuleLabelFormat, errs)
+		}
+		if got := errs[0].JSONPointer(); got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+	}
+
+	data := bundle(validPod, container("\n      ports:\n        - containerPort: 0"))
+	errs := ValidateBytes("pod.yaml", []byte(data), DefaultOptions())
+	if len(errs) != 1 || errs[0].JSONPointer() != "/spec/containers/0/ports/0/containerPort" {
+		t.Errorf("unexpected findings: %v", errs)
+	}
+}
+
+// Нарушения правила rule
+func findingsOfRule(errs []ValidationError, rule string) []ValidationError {
+	var found []ValidationError
+	for _, e := range errs {
+		if e.RuleID == rule {
+			found = append(found, e)
+		}
+	}
+	return found
+}
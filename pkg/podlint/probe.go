@@ -0,0 +1,70 @@
+package podlint
+
+// Обработчики проб, задаётся ровно один
+var probeHandlers = []string{"httpGet", "exec", "tcpSocket", "grpc"}
+
+// Поля времени и порогов проб и их минимальные значения
+var probeTimings = []struct {
+	field string
+	min   int
+}{
+	{"initialDelaySeconds", 0},
+	{"periodSeconds", 1},
+	{"timeoutSeconds", 1},
+	{"failureThreshold", 1},
+	{"successThreshold", 1},
+}
+
+// Проверка пробы name контейнера, path — путь к ней в дереве узлов
+func validateProbe(r *reporter, probe map[string]interface{}, name string, path []interface{}) {
+	// --- обработчик ---
+	var handlers []string
+	for _, handler := range probeHandlers {
+		if _, ok := probe[handler]; ok {
+			handlers = append(handlers, handler)
+		}
+	}
+	switch len(handlers) {
+	case 0:
+		r.errorf(RuleProbeHandler, path, "%s must define one of httpGet, exec, tcpSocket or grpc", name)
+	case 1:
+	default:
+		r.errorf(RuleProbeHandler, subPath(path, handlers[1]), "%s must define only one handler, found %s and %s", name, handlers[0], handlers[1])
+	}
+
+	// --- httpGet.port / tcpSocket.port / grpc.port ---
+	for _, handler := range []string{"httpGet", "tcpSocket", "grpc"} {
+		if action, ok := probe[handler].(map[string]interface{}); ok {
+			if port, ok := action["port"]; ok {
+				if !validatePort(port) {
+					r.errorf(RuleProbePort, subPath(path, handler, "port"), "port value out of range")
+				}
+			}
+		}
+	}
+
+	// --- exec.command ---
+	if exec, ok := probe["exec"].(map[string]interface{}); ok {
+		if command, _ := exec["command"].([]interface{}); len(command) == 0 {
+			r.errorf(RuleProbeHandler, subPath(path, "exec", "command"), "exec.command is required")
+		}
+	}
+
+	// --- время и пороги ---
+	for _, timing := range probeTimings {
+		value, ok := probe[timing.field]
+		if !ok {
+			continue
+		}
+		if n, ok := value.(int); !ok || n < timing.min {
+			r.errorf(RuleProbeTiming, subPath(path, timing.field), "%s must be an integer >= %d", timing.field, timing.min)
+		}
+	}
+
+	// --- successThreshold ---
+	if name == "livenessProbe" || name == "startupProbe" {
+		if n, ok := probe["successThreshold"].(int); ok && n != 1 {
+			r.errorf(RuleProbeTiming, subPath(path, "successThreshold"), "successThreshold must be 1 for %s", name)
+		}
+	}
+}
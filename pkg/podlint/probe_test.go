@@ -0,0 +1,17 @@
+package podlint
+
+import "testing"
+
+var probeRuleCases = []ruleCase{
+	{rule: RuleProbeHandler, yaml: container("\n      livenessProbe:\n        exec: {command: [true]}")},
+	{rule: RuleProbeHandler, fail: true, yaml: container("\n      livenessProbe:\n        periodSeconds: 5")},
+	{rule: RuleProbeHandler, fail: true, yaml: container("\n      livenessProbe:\n        exec: {command: [true]}\n        grpc: {port: 9000}")},
+
+	{rule: RuleProbeTiming, yaml: container("\n      readinessProbe:\n        grpc: {port: 9000}\n        periodSeconds: 10\n        successThreshold: 2")},
+	{rule: RuleProbeTiming, fail: true, yaml: container("\n      readinessProbe:\n        grpc: {port: 9000}\n        periodSeconds: 0")},
+	{rule: RuleProbeTiming, fail: true, yaml: container("\n      livenessProbe:\n        grpc: {port: 9000}\n        successThreshold: 2")},
+}
+
+func TestProbeRules(t *testing.T) {
+	testRuleCases(t, probeRuleCases)
+}
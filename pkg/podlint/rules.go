@@ -0,0 +1,257 @@
+package podlint
+
+// Уровни серьёзности нарушений
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Идентификаторы правил
+const (
+	RuleInput         = "YAML001"
+	RuleNameRequired  = "META001"
+	RuleFieldRequired = "META002"
+	RuleDNSName       = "META003"
+	RuleLabelFormat   = "META004"
+	RuleAnnotation    = "META005"
+	RuleAnnotationReq = "META006"
+	RuleOSUnsupported = "POD001"
+	RuleHAPlacement   = "POD002"
+	RuleReplicas      = "DEP001"
+	RuleSelector      = "DEP002"
+	RuleServiceName   = "STS001"
+	RuleBackoffLimit  = "JOB001"
+	RuleCronSchedule  = "CRON001"
+	RuleServiceType   = "SVC001"
+	RuleServicePort   = "SVC002"
+	RuleTargetPort    = "SVC003"
+	RuleNodePort      = "SVC004"
+	RuleStaleSelector = "SVC005"
+	RuleDataKey       = "CFG001"
+	RuleDataEncoding  = "CFG002"
+	RuleDataSize      = "CFG003"
+	RulePlaintext     = "CFG004"
+	RuleMaxContainers = "BUD001"
+	RulePodBudget     = "BUD002"
+	RuleManifestSize  = "BUD003"
+	RulePathLayout    = "GIT001"
+	RuleVersionSkew   = "VER001"
+	RuleVolume        = "VOL001"
+	RuleVolumeMount   = "VOL002"
+	RuleMountPath     = "VOL003"
+	RulePrivileged    = "SEC001"
+	RuleRunAsNonRoot  = "SEC002"
+	RuleEscalation    = "SEC003"
+	RuleHostPath      = "SEC004"
+	RuleHostNamespace = "SEC005"
+	RuleContainerName = "CTR001"
+	RuleNameFormat    = "CTR002"
+	RuleContainerDup  = "CTR003"
+	RuleImageRegistry = "IMG001"
+	RuleImageTag      = "IMG002"
+	RuleImageEnvTag   = "IMG003"
+	RuleImageLatest   = "IMG004"
+	RuleImageSemver   = "IMG005"
+	RuleImageDigest   = "IMG006"
+	RulePortRange     = "PORT001"
+	RulePortProtocol  = "PORT002"
+	RulePortDuplicate = "PORT003"
+	RulePortConflict  = "PORT004"
+	RuleLowPort       = "PORT005"
+	RuleHostPort      = "PORT006"
+	RuleProbePort     = "PRB001"
+	RuleProbeHandler  = "PRB002"
+	RuleProbeTiming   = "PRB003"
+	RuleCommandString = "CMD001"
+	RuleCommandSplit  = "CMD002"
+	RuleCommandVarRef = "CMD003"
+	RuleEnvReserved   = "ENV001"
+	RuleEnvOverride   = "ENV002"
+	RuleEnvSecret     = "ENV003"
+	RuleEnvName       = "ENV004"
+	RuleEnvSource     = "ENV005"
+	RuleInitProbe     = "INIT001"
+	RuleInitSharedRW  = "INIT002"
+	RuleEphemeral     = "EPH001"
+	RuleQuantity      = "RES001"
+	RuleResourceKey   = "RES002"
+	RuleRequestLimit  = "RES003"
+)
+
+// RuleInfo описывает правило для отчётов.
+type RuleInfo struct {
+	ID          string
+	Description string
+	Severity    string // уровень по умолчанию, меняется через Options.Severities
+}
+
+// Rules — все правила в порядке вывода в метаданных отчёта.
+var Rules = []RuleInfo{
+	{RuleInput, "File must be readable, valid YAML and within the input limits", SeverityError},
+	{RuleNameRequired, "metadata.name is required", SeverityError},
+	{RuleFieldRequired, "Fields listed in requiredFields must be present", SeverityError},
+	{RuleDNSName, "metadata.name and metadata.namespace must be valid DNS-1123 names", SeverityError},
+	{RuleLabelFormat, "Label keys and values must be valid qualified names of limited length", SeverityError},
+	{RuleAnnotation, "Annotation keys must be valid qualified names, total size at most 256KiB", SeverityError},
+	{RuleAnnotationReq, "Annotations listed in requiredAnnotations must be present", SeverityError},
+	{RuleOSUnsupported, "spec.os must be linux or windows", SeverityError},
+	{RuleHAPlacement, "Critical pods must define podAntiAffinity or topologySpreadConstraints", SeverityError},
+	{RuleReplicas, "Workload replicas must not be negative", SeverityError},
+	{RuleSelector, "Workload selector must match pod template labels", SeverityError},
+	{RuleServiceName, "StatefulSet serviceName is required", SeverityError},
+	{RuleBackoffLimit, "Job backoffLimit must not be negative", SeverityError},
+	{RuleCronSchedule, "CronJob schedule must be a valid cron expression", SeverityError},
+	{RuleServiceType, "Service type must be ClusterIP, NodePort, LoadBalancer or ExternalName", SeverityError},
+	{RuleServicePort, "Service port and numeric targetPort must be in range 1-65535", SeverityError},
+	{RuleTargetPort, "Named targetPort must match a container port of the selected pods", SeverityError},
+	{RuleNodePort, "nodePort must be in range 30000-32767 and only used with NodePort or LoadBalancer", SeverityError},
+	{RuleStaleSelector, "Service and NetworkPolicy selectors should select pods defined in the same file (other files are not consulted)", SeverityWarning},
+	{RuleDataKey, "ConfigMap and Secret keys must be valid and unique", SeverityError},
+	{RuleDataEncoding, "Secret data and ConfigMap binaryData values must be base64-encoded", SeverityError},
+	{RuleDataSize, "ConfigMap and Secret data must not exceed 1MiB", SeverityError},
+	{RulePlaintext, "Secret values should not be committed to the repository in plain form", SeverityWarning},
+	{RuleMaxContainers, "Pods should not exceed the configured number of containers", SeverityWarning},
+	{RulePodBudget, "Pod cpu and memory should stay within the configured budget", SeverityWarning},
+	{RuleManifestSize, "Manifest files should stay within the configured size budget", SeverityWarning},
+	{RulePathLayout, "metadata.namespace, metadata.name and kind must agree with the file path layout", SeverityError},
+	{RuleVersionSkew, "API versions and fields must be available in every targeted Kubernetes version", SeverityError},
+	{RuleVolume, "Volumes must have unique names and exactly one source", SeverityError},
+	{RuleVolumeMount, "volumeMounts must refer to a declared volume", SeverityError},
+	{RuleMountPath, "mountPath must be absolute and unique within a container", SeverityError},
+	{RulePrivileged, "Containers must not run privileged", SeverityError},
+	{RuleRunAsNonRoot, "Containers should set runAsNonRoot: true", SeverityWarning},
+	{RuleEscalation, "Containers should set allowPrivilegeEscalation: false", SeverityWarning},
+	{RuleHostPath, "Pods should not mount hostPath volumes", SeverityWarning},
+	{RuleHostNamespace, "Pods should not use hostNetwork, hostPID or hostIPC", SeverityWarning},
+	{RuleContainerName, "Container name is required", SeverityError},
+	{RuleNameFormat, "Container name must match the naming convention", SeverityError},
+	{RuleContainerDup, "Container names must be unique across containers, initContainers and ephemeralContainers", SeverityError},
+	{RuleImageRegistry, "Image must come from an allowed registry", SeverityError},
+	{RuleImageTag, "Image must have a tag", SeverityError},
+	{RuleImageEnvTag, "Image tag must match the pattern of the selected environment", SeverityError},
+	{RuleImageLatest, "Image tag must not be latest", SeverityError},
+	{RuleImageSemver, "Image tag must look like a semantic version", SeverityError},
+	{RuleImageDigest, "Image must be pinned by @sha256 digest", SeverityError},
+	{RulePortRange, "containerPort must be in range 1-65535", SeverityError},
+	{RulePortProtocol, "Container port protocol must be set explicitly", SeverityError},
+	{RulePortDuplicate, "containerPort and protocol pairs must be unique within a container", SeverityError},
+	{RulePortConflict, "containerPort and protocol pairs must be unique across containers of a pod", SeverityError},
+	{RuleLowPort, "Ports below 1024 on the host network need NET_BIND_SERVICE", SeverityWarning},
+	{RuleHostPort, "hostPort must equal containerPort when hostNetwork is true", SeverityError},
+	{RuleProbePort, "Probe httpGet, tcpSocket and grpc port must be in range 1-65535", SeverityError},
+	{RuleProbeHandler, "Probe must define exactly one of httpGet, exec, tcpSocket or grpc", SeverityError},
+	{RuleProbeTiming, "Probe timing and threshold fields must be in range", SeverityError},
+	{RuleCommandString, "Command given as one string with spaces should not be combined with args", SeverityWarning},
+	{RuleCommandSplit, "Each command and args item should hold a single flag", SeverityWarning},
+	{RuleCommandVarRef, "Environment variables in command and args must be referenced as $(VAR)", SeverityWarning},
+	{RuleEnvReserved, "Environment variable names should not collide with service variables injected by Kubernetes", SeverityWarning},
+	{RuleEnvOverride, "env should not silently override variables from envFrom", SeverityWarning},
+	{RuleEnvSecret, "Credentials in env should come from secretKeyRef, not literal values", SeverityWarning},
+	{RuleEnvName, "env names must be unique C identifiers", SeverityError},
+	{RuleEnvSource, "env valueFrom and envFrom must reference exactly one complete source", SeverityError},
+	{RuleInitProbe, "Init containers must not declare probes, Kubernetes ignores them", SeverityWarning},
+	{RuleInitSharedRW, "Init and app containers should not mount the same volume path writable", SeverityWarning},
+	{RuleEphemeral, "Ephemeral containers must not declare ports, probes, resources or lifecycle", SeverityError},
+	{RuleQuantity, "cpu and memory requests and limits must be valid quantities", SeverityError},
+	{RuleResourceKey, "Resource requests and limits may only use allowed keys", SeverityError},
+	{RuleRequestLimit, "Resource requests must not exceed limits", SeverityError},
+}
+
+// LookupRule возвращает описание правила по идентификатору.
+func LookupRule(id string) (RuleInfo, bool) {
+	for _, ri := range Rules {
+		if ri.ID == id {
+			return ri, true
+		}
+	}
+	return RuleInfo{}, false
+}
+
+// ActiveRules возвращает, какие правила выполняются при данных параметрах.
+// Неактивным считается правило, отключённое для всех документов: в
+// DisabledRules или в Overrides без условий. Правило, отключённое только
+// для части документов, остаётся активным; выполнялось ли оно, видно по
+// FileResult.Disabled.
+func ActiveRules(opts Options) map[string]bool {
+	active := make(map[string]bool, len(Rules))
+	for _, ri := range Rules {
+		active[ri.ID] = true
+	}
+	active[RulePortProtocol] = opts.RequireProtocol
+	active[RuleHAPlacement] = opts.HASelector != "" && opts.Fragment == ""
+	active[RuleFieldRequired] = len(opts.RequiredFields) > 0
+	active[RuleAnnotationReq] = len(opts.RequiredAnnotations) > 0
+	active[RuleNameFormat] = opts.ContainerName != nil
+	active[RuleImageRegistry] = len(opts.Registries) > 0
+	active[RuleImageEnvTag] = opts.ImageTag != nil
+	active[RuleImageLatest] = opts.DenyLatest
+	active[RuleImageSemver] = opts.SemverTags
+	active[RuleImageDigest] = opts.RequireDigest
+	active[RuleResourceKey] = len(opts.ResourceKeys) > 0
+	active[RuleMaxContainers] = opts.MaxContainers > 0 && opts.Fragment != FragmentContainer
+	active[RulePodBudget] = (opts.MaxPodCPU.Sign() > 0 || opts.MaxPodMemory.Sign() > 0) && opts.Fragment != FragmentContainer
+	active[RuleManifestSize] = opts.MaxManifestSize > 0
+	active[RulePathLayout] = opts.PathLayout != "" && opts.Fragment == ""
+	active[RuleVersionSkew] = opts.KubernetesVersions != nil && opts.Fragment == ""
+	if opts.Fragment != "" {
+		active[RuleNameRequired] = false
+		active[RuleFieldRequired] = false
+		active[RuleDNSName] = false
+		active[RuleLabelFormat] = false
+		active[RuleAnnotation] = false
+		active[RuleAnnotationReq] = false
+		active[RuleReplicas] = false
+		active[RuleSelector] = false
+		active[RuleServiceName] = false
+		active[RuleBackoffLimit] = false
+		active[RuleCronSchedule] = false
+		active[RuleServiceType] = false
+		active[RuleServicePort] = false
+		active[RuleTargetPort] = false
+		active[RuleNodePort] = false
+		active[RuleStaleSelector] = false
+		active[RuleDataKey] = false
+		active[RuleDataEncoding] = false
+		active[RuleDataSize] = false
+		active[RulePlaintext] = false
+	}
+	if opts.Fragment == FragmentContainer {
+		active[RuleOSUnsupported] = false
+		active[RuleVolume] = false
+		active[RuleVolumeMount] = false
+		active[RuleMountPath] = false
+		for _, id := range []string{RuleContainerDup, RulePortConflict, RuleLowPort, RuleHostPort} {
+			active[id] = false
+		}
+		for _, id := range []string{RulePrivileged, RuleRunAsNonRoot, RuleEscalation, RuleHostPath, RuleHostNamespace} {
+			active[id] = false
+		}
+	}
+	// Отключение без условий действует на все документы, если ни один набор
+	// или переопределение с условиями не включает правило обратно
+	disabled := opts.DisabledRules
+	for _, o := range opts.Overrides {
+		if o.unconditional() {
+			disabled = mergeRules(disabled, o.DisabledRules)
+		}
+	}
+	enabled := make(map[string]bool)
+	for _, pack := range opts.Packs {
+		for id, off := range pack.DisabledRules {
+			enabled[id] = enabled[id] || !off
+		}
+	}
+	for _, o := range opts.Overrides {
+		if !o.unconditional() {
+			for id, off := range o.DisabledRules {
+				enabled[id] = enabled[id] || !off
+			}
+		}
+	}
+	for id, off := range disabled {
+		if off && !enabled[id] {
+			active[id] = false
+		}
+	}
+	return active
+}
@@ -0,0 +1,39 @@
+package podlint
+
+import "testing"
+
+func TestActiveRules(t *testing.T) {
+	active := ActiveRules(DefaultOptions())
+	for rule, want := range map[string]bool{
+		RuleImageTag:      true,
+		RuleNameFormat:    true,
+		RuleImageRegistry: false,
+		RuleResourceKey:   false,
+		RuleRunAsNonRoot:  false,
+		RulePortProtocol:  false,
+	} {
+		if active[rule] != want {
+			t.Errorf("DefaultOptions: %s active = %v, want %v", rule, active[rule], want)
+		}
+	}
+
+	// Неактивно только правило, отключённое для всех документов
+	for name, tt := range map[string]struct {
+		config string
+		active bool
+	}{
+		"override without conditions": {"overrides:\n  - rules:\n      IMG002: {disabled: true}\n", false},
+		"override scoped to files":    {"overrides:\n  - files: [\"jobs/**\"]\n    rules:\n      IMG002: {disabled: true}\n", true},
+		"override scoped to kinds":    {"overrides:\n  - kinds: [Job]\n    rules:\n      IMG002: {disabled: true}\n", true},
+		"pack":                        {"policyPacks:\n  - name: dev\n    namespaceLabels: {tier: dev}\n    rules:\n      IMG002: {disabled: true}\n", true},
+		"disabled, enabled for files": {"rules:\n  IMG002: {disabled: true}\noverrides:\n  - files: [\"prod/**\"]\n    rules:\n      IMG002: {disabled: false}\n", true},
+		"disabled, scoped severity":   {"rules:\n  IMG002: {disabled: true}\noverrides:\n  - files: [\"prod/**\"]\n    rules:\n      IMG002: {severity: warning}\n", false},
+	} {
+		if got := ActiveRules(configOptions(t, tt.config))[RuleImageTag]; got != tt.active {
+			t.Errorf("%s: %s active = %v, want %v", name, RuleImageTag, got, tt.active)
+		}
+	}
+	if !ActiveRules(configOptions(t, "overrides:\n  - files: [\"**\"]\n    rules:\n      IMG002: {severity: warning}\n"))[RuleImageTag] {
+		t.Errorf("severity override: %s reported inactive", RuleImageTag)
+	}
+}
@@ -0,0 +1,53 @@
+package podlint
+
+// Проверка securityContext пода и его контейнеров, томов hostPath и
+// пространств имён узла — упрощённый аналог PodSecurity
+func validateSecurity(r *reporter, spec map[string]interface{}, path []interface{}) {
+	// --- hostNetwork / hostPID / hostIPC ---
+	for _, field := range []string{"hostNetwork", "hostPID", "hostIPC"} {
+		if enabled, _ := spec[field].(bool); enabled {
+			r.errorf(RuleHostNamespace, subPath(path, field), "%s shares the node namespace with the pod", field)
+		}
+	}
+
+	// --- volumes[].hostPath ---
+	volumes, _ := spec["volumes"].([]interface{})
+	for i, item := range volumes {
+		volume, _ := item.(map[string]interface{})
+		if _, ok := volume["hostPath"]; ok {
+			r.errorf(RuleHostPath, subPath(path, "volumes", i, "hostPath"), "volume '%v' mounts a hostPath from the node", volume["name"])
+		}
+	}
+
+	podContext, _ := spec["securityContext"].(map[string]interface{})
+	podNonRoot, _ := podContext["runAsNonRoot"].(bool)
+
+	for _, list := range []string{"initContainers", "containers"} {
+		containers, _ := spec[list].([]interface{})
+		for i, item := range containers {
+			container, _ := item.(map[string]interface{})
+			containerPath := subPath(path, list, i)
+			context, _ := container["securityContext"].(map[string]interface{})
+			contextPath := subPath(containerPath, "securityContext")
+
+			// --- securityContext.privileged ---
+			if privileged, _ := context["privileged"].(bool); privileged {
+				r.errorf(RulePrivileged, subPath(contextPath, "privileged"), "container '%v' is privileged", container["name"])
+			}
+
+			// --- securityContext.runAsNonRoot (контейнера или пода) ---
+			nonRoot := podNonRoot
+			if value, ok := context["runAsNonRoot"].(bool); ok {
+				nonRoot = value
+			}
+			if !nonRoot {
+				r.errorf(RuleRunAsNonRoot, contextPath, "container '%v' may run as root, set runAsNonRoot: true", container["name"])
+			}
+
+			// --- securityContext.allowPrivilegeEscalation ---
+			if escalation, ok := context["allowPrivilegeEscalation"].(bool); !ok || escalation {
+				r.errorf(RuleEscalation, contextPath, "container '%v' allows privilege escalation, set allowPrivilegeEscalation: false", container["name"])
+			}
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package podlint
+
+import "testing"
+
+var securityRuleCases = []ruleCase{
+	{rule: RulePrivileged, yaml: validPod},
+	{rule: RulePrivileged, fail: true, yaml: container("\n      securityContext:\n        privileged: true")},
+
+	// SEC002 и SEC003 по умолчанию отключены
+	{rule: RuleRunAsNonRoot, yaml: validPod,
+		opts: func(o *Options) { o.DisabledRules = nil }},
+	{rule: RuleRunAsNonRoot, yaml: container("")},
+	{rule: RuleRunAsNonRoot, fail: true, yaml: container(""),
+		opts: func(o *Options) { o.DisabledRules = nil }},
+
+	{rule: RuleEscalation, yaml: validPod,
+		opts: func(o *Options) { o.DisabledRules = nil }},
+	{rule: RuleEscalation, yaml: container("")},
+	{rule: RuleEscalation, fail: true, yaml: container("\n      securityContext:\n        allowPrivilegeEscalation: true"),
+		opts: func(o *Options) { o.DisabledRules = nil }},
+
+	{rule: RuleHostPath, yaml: pod("\n  volumes:\n    - name: data\n      emptyDir: {}")},
+	{rule: RuleHostPath, fail: true, yaml: pod("\n  volumes:\n    - name: data\n      hostPath: {path: /var}")},
+
+	{rule: RuleHostNamespace, yaml: pod("\n  hostNetwork: false\n  containers: []")},
+	{rule: RuleHostNamespace, fail: true, yaml: pod("\n  hostPID: true\n  containers: []")},
+}
+
+func TestSecurityRules(t *testing.T) {
+	testRuleCases(t, securityRuleCases)
+}
@@ -0,0 +1,188 @@
+package podlint
+
+import "fmt"
+
+// Допустимые значения spec.type сервиса
+var serviceTypes = map[string]bool{
+	"ClusterIP":    true,
+	"NodePort":     true,
+	"LoadBalancer": true,
+	"ExternalName": true,
+}
+
+// Проверка Service: тип, порты и ссылки targetPort на порты контейнеров
+func validateService(r *reporter, spec map[string]interface{}) {
+	path := []interface{}{"spec"}
+
+	// --- spec.type ---
+	serviceType := "ClusterIP"
+	if value, ok := spec["type"]; ok {
+		serviceType = fmt.Sprint(value)
+		if !serviceTypes[serviceType] {
+			r.errorf(RuleServiceType, subPath(path, "type"), "type has unsupported value '%s'", serviceType)
+		}
+	}
+
+	selector, _ := spec["selector"].(map[string]interface{})
+	if len(selector) > 0 {
+		if hint := r.unselected(selector); hint != "" {
+			r.errorf(RuleStaleSelector, subPath(path, "selector"), "selector matches no pods in this file; %s", hint)
+		}
+	}
+
+	ports, _ := spec["ports"].([]interface{})
+	for i, item := range ports {
+		port, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		// --- spec.ports[].port ---
+		if value, ok := port["port"]; !ok || !validatePort(value) {
+			r.errorf(RuleServicePort, subPath(path, "ports", i, "port"), "port value out of range")
+		}
+
+		// --- spec.ports[].targetPort ---
+		switch target := port["targetPort"].(type) {
+		case nil:
+		case string:
+			if len(selector) > 0 && !r.namedPortSelected(selector, target) {
+				r.errorf(RuleTargetPort, subPath(path, "ports", i, "targetPort"),
+					"targetPort '%s' does not match a named container port of the selected pods", target)
+			}
+		default:
+			if !validatePort(target) {
+				r.errorf(RuleServicePort, subPath(path, "ports", i, "targetPort"), "targetPort value out of range")
+			}
+		}
+
+		// --- spec.ports[].nodePort ---
+		if nodePort, ok := port["nodePort"]; ok {
+			if serviceType != "NodePort" && serviceType != "LoadBalancer" {
+				r.errorf(RuleNodePort, subPath(path, "ports", i, "nodePort"), "nodePort is not allowed for type %s", serviceType)
+			} else if n, ok := nodePort.(int); !ok || n < 30000 || n > 32767 {
+				r.errorf(RuleNodePort, subPath(path, "ports", i, "nodePort"), "nodePort value out of range 30000-32767")
+			}
+		}
+	}
+}
+
+// Объявлен ли порт name у подов из документов файла, подходящих под
+// селектор. Если таких подов в файле нет, ссылку проверить нельзя и она
+// считается верной.
+func (r *reporter) namedPortSelected(selector map[string]interface{}, name string) bool {
+	selected := false
+	for _, doc := range r.docs {
+		labels, spec := podTemplate(doc)
+		if spec == nil || !selectorMatches(selector, labels) {
+			continue
+		}
+		selected = true
+		containers, _ := spec["containers"].([]interface{})
+		for _, c := range containers {
+			container, _ := c.(map[string]interface{})
+			ports, _ := container["ports"].([]interface{})
+			for _, p := range ports {
+				port, _ := p.(map[string]interface{})
+				if port["name"] == name {
+					return true
+				}
+			}
+		}
+	}
+	return !selected
+}
+
+// Проверка NetworkPolicy: podSelector.matchLabels должен выбирать поды
+// из документов файла. Пустой селектор выбирает все поды пространства имён.
+func validateNetworkPolicy(r *reporter, spec map[string]interface{}) {
+	podSelector, _ := spec["podSelector"].(map[string]interface{})
+	matchLabels, _ := podSelector["matchLabels"].(map[string]interface{})
+	if len(matchLabels) == 0 || podSelector["matchExpressions"] != nil {
+		return
+	}
+	if hint := r.unselected(matchLabels); hint != "" {
+		r.errorf(RuleStaleSelector, []interface{}{"spec", "podSelector", "matchLabels"}, "podSelector matches no pods in this file; %s", hint)
+	}
+}
+
+// Пояснение, если селектор не выбирает ни одного пода из документов файла в
+// пространстве имён проверяемого документа: ближайший под и отличающаяся
+// метка. "" — селектор выбирает под или подов в файле нет, и проверить
+// ссылку нельзя.
+//
+// Проверка ведётся в пределах файла: файлы проверяются независимо и
+// параллельно, поэтому поды из других файлов прогона не учитываются.
+func (r *reporter) unselected(selector map[string]interface{}) string {
+	namespace := ""
+	if r.document < len(r.docs) {
+		namespace = r.docs[r.document].Namespace()
+	}
+	hint, best := "", -1
+	for _, doc := range r.docs {
+		labels, spec := podTemplate(doc)
+		if spec == nil || doc.Namespace() != namespace {
+			continue
+		}
+		if selectorMatches(selector, labels) {
+			return ""
+		}
+
+		// Ближайший под — с наибольшим числом совпавших меток селектора
+		matched, missed := 0, ""
+		for _, key := range sortedKeys(selector) {
+			value, ok := labels[key]
+			switch {
+			case ok && fmt.Sprint(value) == fmt.Sprint(selector[key]):
+				matched++
+			case missed != "":
+			case ok:
+				missed = fmt.Sprintf("label %s is '%v', not '%v'", key, value, selector[key])
+			default:
+				missed = fmt.Sprintf("label %s is missing", key)
+			}
+		}
+		if matched > best {
+			best = matched
+			hint = fmt.Sprintf("closest is %s '%s', where %s", doc.Kind(), doc.Name(), missed)
+		}
+	}
+	return hint
+}
+
+// Метки и PodSpec пода, который создаёт документ: самого Pod или шаблона
+// контроллера. Для остальных kind spec равен nil.
+func podTemplate(doc *Document) (labels, spec map[string]interface{}) {
+	raw := doc.raw
+	kind := doc.Kind()
+	switch {
+	case kind == "Pod":
+	case kind == "CronJob":
+		raw = nested(raw, "spec", "jobTemplate", "spec", "template")
+	case workloadKinds[kind]:
+		raw = nested(raw, "spec", "template")
+	default:
+		return nil, nil
+	}
+	labels = nested(raw, "metadata", "labels")
+	spec = nested(raw, "spec")
+	return labels, spec
+}
+
+// Вложенный объект по цепочке ключей или nil
+func nested(m map[string]interface{}, keys ...string) map[string]interface{} {
+	for _, key := range keys {
+		m, _ = m[key].(map[string]interface{})
+	}
+	return m
+}
+
+// Подходят ли метки под селектор вида matchLabels (все пары совпадают)
+func selectorMatches(selector, labels map[string]interface{}) bool {
+	for key, value := range selector {
+		if v, ok := labels[key]; !ok || fmt.Sprint(v) != fmt.Sprint(value) {
+			return false
+		}
+	}
+	return true
+}
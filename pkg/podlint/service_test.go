@@ -0,0 +1,32 @@
+package podlint
+
+import "testing"
+
+var serviceRuleCases = []ruleCase{
+	{rule: RuleServiceType, yaml: service("\n  type: NodePort")},
+	{rule: RuleServiceType, fail: true, yaml: service("\n  type: Internal")},
+
+	{rule: RuleServicePort, yaml: service("\n  ports:\n    - port: 80\n      targetPort: 8080")},
+	{rule: RuleServicePort, fail: true, yaml: service("\n  ports:\n    - port: 70000")},
+	{rule: RuleServicePort, fail: true, yaml: service("\n  ports:\n    - port: 80\n      targetPort: 0")},
+
+	{rule: RuleTargetPort, yaml: bundle(container("\n      ports:\n        - name: http\n          containerPort: 8080"),
+		service("\n  selector: {app: web}\n  ports:\n    - port: 80\n      targetPort: http"))},
+	{rule: RuleTargetPort, fail: true, yaml: bundle(validPod,
+		service("\n  selector: {app: web}\n  ports:\n    - port: 80\n      targetPort: http"))},
+
+	{rule: RuleNodePort, yaml: service("\n  type: NodePort\n  ports:\n    - port: 80\n      nodePort: 30080")},
+	{rule: RuleNodePort, fail: true, yaml: service("\n  ports:\n    - port: 80\n      nodePort: 30080")},
+	{rule: RuleNodePort, fail: true, yaml: service("\n  type: NodePort\n  ports:\n    - port: 80\n      nodePort: 80")},
+
+	{rule: RuleStaleSelector, yaml: bundle(validPod, service("\n  selector: {app: web}"))},
+	// Поды ищутся только в том же файле; если их нет, селектор не проверяется
+	{rule: RuleStaleSelector, yaml: service("\n  selector: {app: api}")},
+	{rule: RuleStaleSelector, fail: true, yaml: bundle(validPod, service("\n  selector: {app: api}"))},
+	{rule: RuleStaleSelector, fail: true, yaml: bundle(validPod,
+		workload("networking.k8s.io/v1", "NetworkPolicy", "\n  podSelector:\n    matchLabels: {app: api}"))},
+}
+
+func TestServiceRules(t *testing.T) {
+	testRuleCases(t, serviceRuleCases)
+}
@@ -0,0 +1,125 @@
+package podlint
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Source — источник проверяемых файлов: каталог на диске, stdin, URL или
+// данные в памяти встраивающей программы.
+type Source interface {
+	// Files возвращает имена файлов источника в порядке проверки.
+	Files() ([]string, error)
+	// Open открывает файл с именем из Files.
+	Open(name string) (io.ReadCloser, error)
+}
+
+// SourceFactory создаёт источник по аргументу командной строки; recursive —
+// обход подкаталогов, если источник их поддерживает.
+type SourceFactory func(arg string, recursive bool) (Source, error)
+
+// SchemeStdin — схема, которой открывается аргумент "-".
+const SchemeStdin = "stdin"
+
+var (
+	sourcesMu sync.RWMutex
+	sources   = make(map[string]SourceFactory)
+)
+
+// RegisterSource регистрирует источник для аргументов вида "<scheme>://...".
+// Схема "" — аргументы без схемы (пути), SchemeStdin — аргумент "-".
+// Повторная регистрация схемы заменяет прежний источник.
+func RegisterSource(scheme string, factory SourceFactory) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	sources[scheme] = factory
+}
+
+// SourceScheme возвращает схему аргумента: часть до "://", SchemeStdin для
+// "-" или "" для обычного пути.
+func SourceScheme(arg string) string {
+	if arg == "-" {
+		return SchemeStdin
+	}
+	if scheme, _, ok := strings.Cut(arg, "://"); ok && scheme != "" && !strings.ContainsAny(scheme, `/\`) {
+		return scheme
+	}
+	return ""
+}
+
+// OpenSource открывает источник, зарегистрированный для схемы аргумента.
+func OpenSource(arg string, recursive bool) (Source, error) {
+	scheme := SourceScheme(arg)
+	sourcesMu.RLock()
+	factory, ok := sources[scheme]
+	sourcesMu.RUnlock()
+	if !ok {
+		if scheme == "" {
+			return nil, fmt.Errorf("%s: no source registered for file paths", arg)
+		}
+		return nil, fmt.Errorf("%s: unsupported source '%s'", arg, scheme)
+	}
+	return factory(arg, recursive)
+}
+
+// MemorySource — файлы в памяти: имя и содержимое. Файлы проверяются в
+// порядке сортировки имён.
+type MemorySource map[string][]byte
+
+func (m MemorySource) Files() ([]string, error) {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (m MemorySource) Open(name string) (io.ReadCloser, error) {
+	data, ok := m[name]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, os.ErrNotExist)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// ValidateSource проверяет все файлы источника по очереди. Файл, который не
+// удалось прочитать, даёт нарушение RuleInput, остальные проверяются дальше.
+func ValidateSource(src Source, opts Options) ([]FileResult, error) {
+	names, err := src.Files()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]FileResult, 0, len(names))
+	for _, name := range names {
+		data, err := readSource(src, name, opts.MaxFileSize)
+		if err != nil {
+			failure := *inputError("unable to read file: %v", err)
+			failure.File = name
+			results = append(results, FileResult{Name: name, Errors: []ValidationError{failure}})
+			continue
+		}
+		results = append(results, ValidateFile(name, data, opts))
+	}
+	return results, nil
+}
+
+// Чтение файла источника не больше limit+1 байт (0 — без ограничения), чтобы
+// превышение размера сообщил Parse
+func readSource(src Source, name string, limit int64) ([]byte, error) {
+	rc, err := src.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	var r io.Reader = rc
+	if limit > 0 {
+		r = io.LimitReader(rc, limit+1)
+	}
+	return io.ReadAll(r)
+}
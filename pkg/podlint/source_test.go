@@ -0,0 +1,35 @@
+package podlint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInputLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		opts func(*Options)
+		want string
+	}{
+		{"size", validPod, func(o *Options) { o.MaxFileSize = 100 }, "file size exceeds limit of 100 bytes"},
+		{"documents", bundle(validPod, validPod, validPod), func(o *Options) { o.MaxDocuments = 2 }, "number of documents exceeds limit of 2"},
+		{"depth", "a: " + strings.Repeat("[", 20) + strings.Repeat("]", 20), func(o *Options) { o.MaxDepth = 10 }, "nesting depth exceeds limit of 10"},
+		// Ограничение размера относится к исходным байтам, до нормализации
+		{"size before normalization", strings.ReplaceAll("\ufeff"+validPod, "\n", "\r\n"),
+			func(o *Options) { o.MaxFileSize = int64(len(validPod)) }, "file size exceeds limit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			tt.opts(&opts)
+			res := ValidateFile("pod.yaml", []byte(tt.data), opts)
+			if len(res.Errors) != 1 || res.Errors[0].RuleID != RuleInput || !strings.Contains(res.Errors[0].Message, tt.want) {
+				t.Errorf("got %v, want a single %s finding with %q", res.Errors, RuleInput, tt.want)
+			}
+			if res.Documents != nil {
+				t.Errorf("Documents = %v, want nil", res.Documents)
+			}
+		})
+	}
+}
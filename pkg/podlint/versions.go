@@ -0,0 +1,212 @@
+package podlint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version — минорная версия Kubernetes, например 1.27.
+type Version struct {
+	Major, Minor int
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// Раньше ли v, чем other
+func (v Version) less(other Version) bool {
+	return v.Major < other.Major || v.Major == other.Major && v.Minor < other.Minor
+}
+
+// ParseVersion разбирает версию вида "1.27" или "v1.27"; патч-версия игнорируется.
+func ParseVersion(s string) (Version, error) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(s), "v"), ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid Kubernetes version '%s', expected e.g. 1.27", s)
+	}
+	major, err1 := strconv.Atoi(parts[0])
+	minor, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil || major < 1 || minor < 0 {
+		return Version{}, fmt.Errorf("invalid Kubernetes version '%s', expected e.g. 1.27", s)
+	}
+	return Version{major, minor}, nil
+}
+
+// VersionRange — диапазон версий кластеров, на которые рассчитаны манифесты.
+type VersionRange struct {
+	Min, Max Version
+}
+
+func (vr VersionRange) String() string {
+	if vr.Min == vr.Max {
+		return vr.Min.String()
+	}
+	return vr.Min.String() + "-" + vr.Max.String()
+}
+
+// ParseVersionRange разбирает "1.27-1.29" (допускается и тире "–") или одну версию "1.28".
+func ParseVersionRange(s string) (VersionRange, error) {
+	low, high, isRange := strings.Cut(strings.ReplaceAll(s, "–", "-"), "-")
+	min, err := ParseVersion(low)
+	if err != nil {
+		return VersionRange{}, err
+	}
+	max := min
+	if isRange {
+		if max, err = ParseVersion(high); err != nil {
+			return VersionRange{}, err
+		}
+	}
+	if max.less(min) {
+		return VersionRange{}, fmt.Errorf("invalid Kubernetes version range '%s': %s is lower than %s", s, max, min)
+	}
+	return VersionRange{min, max}, nil
+}
+
+// Возможность, доступная начиная с since и до removed (нулевые — без ограничения)
+type availability struct {
+	since, removed Version
+}
+
+// Почему возможность недоступна в части диапазона; "" — доступна во всём
+func (a availability) check(vr VersionRange) string {
+	if a.since != (Version{}) && vr.Min.less(a.since) {
+		return fmt.Sprintf("requires Kubernetes %s or later", a.since)
+	}
+	if a.removed != (Version{}) && !vr.Max.less(a.removed) {
+		return fmt.Sprintf("was removed in Kubernetes %s", a.removed)
+	}
+	return ""
+}
+
+// Версии API по kind
+var apiVersions = map[string]map[string]availability{
+	"Deployment": {
+		"apps/v1":            {since: Version{1, 9}},
+		"apps/v1beta1":       {removed: Version{1, 16}},
+		"apps/v1beta2":       {removed: Version{1, 16}},
+		"extensions/v1beta1": {removed: Version{1, 16}},
+	},
+	"DaemonSet": {
+		"apps/v1":            {since: Version{1, 9}},
+		"apps/v1beta2":       {removed: Version{1, 16}},
+		"extensions/v1beta1": {removed: Version{1, 16}},
+	},
+	"StatefulSet": {
+		"apps/v1":      {since: Version{1, 9}},
+		"apps/v1beta1": {removed: Version{1, 16}},
+		"apps/v1beta2": {removed: Version{1, 16}},
+	},
+	"CronJob": {
+		"batch/v1":      {since: Version{1, 21}},
+		"batch/v1beta1": {removed: Version{1, 25}},
+	},
+	"PodDisruptionBudget": {
+		"policy/v1":      {since: Version{1, 21}},
+		"policy/v1beta1": {removed: Version{1, 25}},
+	},
+	"HorizontalPodAutoscaler": {
+		"autoscaling/v2":      {since: Version{1, 23}},
+		"autoscaling/v2beta1": {removed: Version{1, 25}},
+		"autoscaling/v2beta2": {removed: Version{1, 26}},
+	},
+	"Ingress": {
+		"networking.k8s.io/v1":      {since: Version{1, 19}},
+		"networking.k8s.io/v1beta1": {removed: Version{1, 22}},
+		"extensions/v1beta1":        {removed: Version{1, 22}},
+	},
+}
+
+// Поля PodSpec, контейнеров и проб, появившиеся в поздних версиях
+// (учитывается версия, в которой поле включено по умолчанию)
+var (
+	podSpecFields = map[string]availability{
+		"os":                  {since: Version{1, 25}},
+		"ephemeralContainers": {since: Version{1, 25}},
+		"schedulingGates":     {since: Version{1, 27}},
+	}
+	containerFields = map[string]availability{
+		"startupProbe": {since: Version{1, 20}},
+		"resizePolicy": {since: Version{1, 33}},
+	}
+	initContainerFields = map[string]availability{
+		"restartPolicy": {since: Version{1, 29}},
+	}
+	probeFields = map[string]availability{
+		"grpc": {since: Version{1, 24}},
+	}
+	cronJobFields = map[string]availability{
+		"timeZone": {since: Version{1, 25}},
+	}
+)
+
+// Проверка, что версия API и поля документа доступны во всём диапазоне vr
+func validateVersions(r *reporter, doc *Document, vr VersionRange) {
+	kind := doc.Kind()
+	if a, ok := apiVersions[kind][doc.APIVersion()]; ok {
+		if reason := a.check(vr); reason != "" {
+			r.errorf(RuleVersionSkew, []interface{}{"apiVersion"}, "%s %s %s, target range is %s", doc.APIVersion(), kind, reason, vr)
+		}
+	}
+
+	var specPath []interface{}
+	switch {
+	case kind == "" || kind == "Pod":
+		specPath = []interface{}{"spec"}
+	case kind == "CronJob":
+		checkFields(r, doc.raw, []interface{}{"spec"}, cronJobFields, vr)
+		specPath = []interface{}{"spec", "jobTemplate", "spec", "template", "spec"}
+	case workloadKinds[kind]:
+		specPath = []interface{}{"spec", "template", "spec"}
+	default:
+		return
+	}
+
+	spec := checkFields(r, doc.raw, specPath, podSpecFields, vr)
+	for _, list := range []string{"initContainers", "containers", "ephemeralContainers"} {
+		containers, _ := spec[list].([]interface{})
+		for i := range containers {
+			path := subPath(specPath, list, i)
+			container := checkFields(r, doc.raw, path, containerFields, vr)
+			if list == "initContainers" {
+				checkFields(r, doc.raw, path, initContainerFields, vr)
+			}
+			for _, probe := range []string{"readinessProbe", "livenessProbe", "startupProbe"} {
+				if _, ok := container[probe]; ok {
+					checkFields(r, doc.raw, subPath(path, probe), probeFields, vr)
+				}
+			}
+		}
+	}
+}
+
+// Проверка полей объекта по пути path; возвращает сам объект
+func checkFields(r *reporter, raw map[string]interface{}, path []interface{}, fields map[string]availability, vr VersionRange) map[string]interface{} {
+	var value interface{} = raw
+	for _, p := range path {
+		switch key := p.(type) {
+		case string:
+			m, _ := value.(map[string]interface{})
+			value = m[key]
+		case int:
+			list, _ := value.([]interface{})
+			if key >= len(list) {
+				return nil
+			}
+			value = list[key]
+		}
+	}
+	obj, _ := value.(map[string]interface{})
+	for _, name := range sortedKeys(obj) {
+		a, ok := fields[name]
+		if !ok {
+			continue
+		}
+		if reason := a.check(vr); reason != "" {
+			r.errorf(RuleVersionSkew, subPath(path, name), "%s %s, target range is %s", name, reason, vr)
+		}
+	}
+	return obj
+}
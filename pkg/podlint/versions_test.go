@@ -0,0 +1,24 @@
+package podlint
+
+import "testing"
+
+var versionRuleCases = []ruleCase{
+	{rule: RuleVersionSkew, yaml: workload("batch/v1", "CronJob", "\n  schedule: \"@daily\""),
+		opts: func(o *Options) { o.KubernetesVersions = mustVersions("1.24-1.30") }},
+	{rule: RuleVersionSkew, fail: true, yaml: workload("batch/v1beta1", "CronJob", "\n  schedule: \"@daily\""),
+		opts: func(o *Options) { o.KubernetesVersions = mustVersions("1.24-1.30") }},
+	{rule: RuleVersionSkew, fail: true, yaml: pod("\n  os: linux\n  containers: []"),
+		opts: func(o *Options) { o.KubernetesVersions = mustVersions("1.24-1.30") }},
+}
+
+func TestVersionRules(t *testing.T) {
+	testRuleCases(t, versionRuleCases)
+}
+
+func mustVersions(s string) *VersionRange {
+	vr, err := ParseVersionRange(s)
+	if err != nil {
+		panic(err)
+	}
+	return &vr
+}
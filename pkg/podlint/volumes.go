@@ -0,0 +1,67 @@
+package podlint
+
+import "strings"
+
+// Проверка spec.volumes: уникальные имена и ровно один источник у тома.
+// Возвращает объявленные имена томов.
+func validateVolumes(r *reporter, spec map[string]interface{}, path []interface{}) map[string]bool {
+	declared := make(map[string]bool)
+	first := make(map[string]int) // индекс первого тома с этим именем
+	volumes, _ := spec["volumes"].([]interface{})
+	for i, item := range volumes {
+		volume, _ := item.(map[string]interface{})
+		name, _ := volume["name"].(string)
+		if name == "" {
+			r.errorf(RuleVolume, subPath(path, "volumes", i, "name"), "name is required")
+		} else if declared[name] {
+			r.relatedf(RuleVolume, subPath(path, "volumes", i, "name"),
+				[]Location{r.location(subPath(path, "volumes", first[name], "name"), "first declared here")},
+				"volume '%s' is duplicated", name)
+		} else {
+			first[name] = i
+		}
+		declared[name] = true
+
+		// Источник — любое поле, кроме name
+		var sources []string
+		for _, key := range sortedKeys(volume) {
+			if key != "name" {
+				sources = append(sources, key)
+			}
+		}
+		if len(sources) != 1 {
+			r.errorf(RuleVolume, subPath(path, "volumes", i), "volume '%s' must define exactly one source, found %d", name, len(sources))
+		}
+	}
+	return declared
+}
+
+// Проверка volumeMounts контейнера: ссылка на объявленный том, абсолютный
+// и неповторяющийся mountPath
+func validateMounts(r *reporter, container map[string]interface{}, path []interface{}, declared map[string]bool) {
+	mountPaths := make(map[string]int) // mountPath — индекс первого монтирования
+	mounts, _ := container["volumeMounts"].([]interface{})
+	for i, item := range mounts {
+		mount, _ := item.(map[string]interface{})
+
+		// --- volumeMounts[].name ---
+		name, _ := mount["name"].(string)
+		if !declared[name] {
+			r.errorf(RuleVolumeMount, subPath(path, "volumeMounts", i, "name"), "volumeMount refers to undeclared volume '%s'", name)
+		}
+
+		// --- volumeMounts[].mountPath ---
+		mountPath, _ := mount["mountPath"].(string)
+		first, seen := mountPaths[mountPath]
+		switch {
+		case !strings.HasPrefix(mountPath, "/"):
+			r.errorf(RuleMountPath, subPath(path, "volumeMounts", i, "mountPath"), "mountPath must be absolute, got '%s'", mountPath)
+		case seen:
+			r.relatedf(RuleMountPath, subPath(path, "volumeMounts", i, "mountPath"),
+				[]Location{r.location(subPath(path, "volumeMounts", first, "mountPath"), "first mounted here")},
+				"mountPath '%s' is duplicated", mountPath)
+		default:
+			mountPaths[mountPath] = i
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package podlint
+
+import "testing"
+
+var volumeRuleCases = []ruleCase{
+	{rule: RuleVolume, yaml: pod("\n  volumes:\n    - name: data\n      emptyDir: {}")},
+	{rule: RuleVolume, fail: true, yaml: pod("\n  volumes:\n    - name: data\n      emptyDir: {}\n    - name: data\n      emptyDir: {}")},
+	{rule: RuleVolume, fail: true, yaml: pod("\n  volumes:\n    - name: data")},
+
+	{rule: RuleVolumeMount, yaml: pod("\n  volumes:\n    - name: data\n      emptyDir: {}" +
+		"\n  containers:\n    - name: web\n      image: web:1\n      volumeMounts:\n        - name: data\n          mountPath: /data")},
+	{rule: RuleVolumeMount, fail: true, yaml: container("\n      volumeMounts:\n        - name: data\n          mountPath: /data")},
+
+	{rule: RuleMountPath, yaml: container("\n      volumeMounts:\n        - name: a\n          mountPath: /a\n        - name: b\n          mountPath: /b")},
+	{rule: RuleMountPath, fail: true, yaml: container("\n      volumeMounts:\n        - name: a\n          mountPath: data")},
+	{rule: RuleMountPath, fail: true, yaml: container("\n      volumeMounts:\n        - name: a\n          mountPath: /a\n        - name: b\n          mountPath: /a")},
+}
+
+func TestVolumeRules(t *testing.T) {
+	testRuleCases(t, volumeRuleCases)
+}
@@ -0,0 +1,157 @@
+package podlint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Контроллеры, шаблон пода которых проверяется так же, как Pod
+var workloadKinds = map[string]bool{
+	"Deployment":  true,
+	"StatefulSet": true,
+	"DaemonSet":   true,
+	"Job":         true,
+	"CronJob":     true,
+}
+
+// Проверка контроллера: поля своего kind и шаблон пода
+func validateWorkload(r *reporter, kind string, spec map[string]interface{}, opts Options) {
+	path := []interface{}{"spec"}
+
+	// --- spec.replicas ---
+	if kind == "Deployment" || kind == "StatefulSet" {
+		if replicas, ok := spec["replicas"].(int); ok && replicas < 0 {
+			r.errorf(RuleReplicas, subPath(path, "replicas"), "replicas must not be negative")
+		}
+	}
+
+	switch kind {
+	case "StatefulSet":
+		// --- spec.serviceName ---
+		if name, ok := spec["serviceName"].(string); !ok || name == "" {
+			r.errorf(RuleServiceName, subPath(path, "serviceName"), "serviceName is required")
+		}
+	case "Job":
+		validateJobSpec(r, spec, path, opts)
+		return
+	case "CronJob":
+		// --- spec.schedule ---
+		if schedule, ok := spec["schedule"].(string); !ok || schedule == "" {
+			r.errorf(RuleCronSchedule, subPath(path, "schedule"), "schedule is required")
+		} else if !validCron(schedule) {
+			r.errorf(RuleCronSchedule, subPath(path, "schedule"), "schedule has invalid format '%s'", schedule)
+		}
+
+		// --- spec.jobTemplate.spec ---
+		jobTemplate, _ := spec["jobTemplate"].(map[string]interface{})
+		if jobSpec, ok := jobTemplate["spec"].(map[string]interface{}); ok {
+			validateJobSpec(r, jobSpec, subPath(path, "jobTemplate", "spec"), opts)
+		}
+		return
+	}
+	validateTemplate(r, spec, path, opts)
+}
+
+// Проверка JobSpec, path — путь к нему в дереве узлов
+func validateJobSpec(r *reporter, spec map[string]interface{}, path []interface{}, opts Options) {
+	// --- backoffLimit ---
+	if limit, ok := spec["backoffLimit"].(int); ok && limit < 0 {
+		r.errorf(RuleBackoffLimit, subPath(path, "backoffLimit"), "backoffLimit must not be negative")
+	}
+	validateTemplate(r, spec, path, opts)
+}
+
+// Проверка селектора и шаблона пода в spec контроллера
+func validateTemplate(r *reporter, spec map[string]interface{}, path []interface{}, opts Options) {
+	template, _ := spec["template"].(map[string]interface{})
+	metadata, _ := template["metadata"].(map[string]interface{})
+	labels, _ := metadata["labels"].(map[string]interface{})
+
+	// --- selector.matchLabels ---
+	selector, _ := spec["selector"].(map[string]interface{})
+	matchLabels, _ := selector["matchLabels"].(map[string]interface{})
+	for _, key := range sortedKeys(matchLabels) {
+		if fmt.Sprint(labels[key]) != fmt.Sprint(matchLabels[key]) {
+			r.errorf(RuleSelector, subPath(path, "selector", "matchLabels", key),
+				"selector label '%s' does not match template labels", key)
+		}
+	}
+
+	// --- template.metadata.labels и annotations ---
+	validateLabels(r, metadata, subPath(path, "template", "metadata"))
+	validateAnnotations(r, metadata, subPath(path, "template", "metadata"))
+
+	// --- template.spec ---
+	if podSpec, ok := template["spec"].(map[string]interface{}); ok {
+		validatePodSpec(r, metadata, podSpec, subPath(path, "template", "spec"), opts)
+	}
+}
+
+// Поля расписания cron: границы значений и допустимые имена
+var cronFields = []struct {
+	min, max int
+	names    []string // имена для значений начиная с min
+}{
+	{0, 59, nil},
+	{0, 23, nil},
+	{1, 31, nil},
+	{1, 12, []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{0, 6, []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// Корректно ли расписание CronJob: пять полей или макрос вида @daily
+func validCron(schedule string) bool {
+	switch schedule {
+	case "@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly":
+		return true
+	}
+	fields := strings.Fields(schedule)
+	if len(fields) != len(cronFields) {
+		return false
+	}
+	for i, field := range fields {
+		for _, item := range strings.Split(field, ",") {
+			if !validCronItem(item, i) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Элемент поля cron: "*", "?" (день месяца и недели), значение или
+// диапазон "a-b", с необязательным шагом "/n"
+func validCronItem(item string, field int) bool {
+	bounds := cronFields[field]
+	item, step, hasStep := strings.Cut(item, "/")
+	if hasStep {
+		if n, err := strconv.Atoi(step); err != nil || n < 1 {
+			return false
+		}
+	}
+	if item == "*" || (item == "?" && (field == 2 || field == 4)) {
+		return true
+	}
+	low, high, isRange := strings.Cut(item, "-")
+	from, ok := cronValue(low, bounds.min, bounds.max, bounds.names)
+	if !ok {
+		return false
+	}
+	if !isRange {
+		return true
+	}
+	to, ok := cronValue(high, bounds.min, bounds.max, bounds.names)
+	return ok && from <= to
+}
+
+// Значение поля cron: число в границах или имя месяца/дня недели
+func cronValue(s string, min, max int, names []string) (int, bool) {
+	for i, name := range names {
+		if strings.EqualFold(s, name) {
+			return min + i, true
+		}
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil && n >= min && n <= max
+}
@@ -0,0 +1,26 @@
+package podlint
+
+import "testing"
+
+var workloadRuleCases = []ruleCase{
+	{rule: RuleReplicas, yaml: workload("apps/v1", "Deployment", "\n  replicas: 2"+template)},
+	{rule: RuleReplicas, fail: true, yaml: workload("apps/v1", "Deployment", "\n  replicas: -1"+template)},
+
+	{rule: RuleSelector, yaml: workload("apps/v1", "Deployment", "\n  selector:\n    matchLabels: {app: web}"+template)},
+	{rule: RuleSelector, fail: true, yaml: workload("apps/v1", "Deployment", "\n  selector:\n    matchLabels: {app: api}"+template)},
+
+	{rule: RuleServiceName, yaml: workload("apps/v1", "StatefulSet", "\n  serviceName: web"+template)},
+	{rule: RuleServiceName, fail: true, yaml: workload("apps/v1", "StatefulSet", template)},
+
+	{rule: RuleBackoffLimit, yaml: workload("batch/v1", "Job", "\n  backoffLimit: 3"+template)},
+	{rule: RuleBackoffLimit, fail: true, yaml: workload("batch/v1", "Job", "\n  backoffLimit: -1"+template)},
+
+	{rule: RuleCronSchedule, yaml: workload("batch/v1", "CronJob", "\n  schedule: \"*/5 * * * MON-FRI\"")},
+	{rule: RuleCronSchedule, yaml: workload("batch/v1", "CronJob", "\n  schedule: \"@daily\"")},
+	{rule: RuleCronSchedule, fail: true, yaml: workload("batch/v1", "CronJob", "\n  schedule: \"61 * * * *\"")},
+	{rule: RuleCronSchedule, fail: true, yaml: workload("batch/v1", "CronJob", "\n  jobTemplate: {}")},
+}
+
+func TestWorkloadRules(t *testing.T) {
+	testRuleCases(t, workloadRuleCases)
+}
@@ -0,0 +1,30 @@
+package quantity
+
+import (
+	"testing"
+)
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"100m", "1Gi", "1.5Gi", "1e3", "1E-3", "0.5", "-1", "+.5k",
+		"", "e", "1e", "1e2000000000", "1..0", "1Kib", "99999999999999999999Ei",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		q, err := Parse(s)
+		if err != nil {
+			return
+		}
+		// Разобранная величина должна разбираться повторно в то же значение
+		again, err := Parse(q.String())
+		if err != nil {
+			t.Fatalf("Parse(%q) ok, but Parse(String()=%q): %v", s, q.String(), err)
+		}
+		if again.Cmp(q) != 0 {
+			t.Fatalf("Parse(%q) = %v, reparsed %v", s, q, again)
+		}
+		q.Value()
+		q.Add(q).Sign()
+	})
+}
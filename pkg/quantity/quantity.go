@@ -0,0 +1,176 @@
+// Package quantity разбирает величины ресурсов Kubernetes ("500m", "1.5Gi",
+// "128M", "1e3") и длительности ("30s", "5"), как их понимает валидатор.
+//
+// Разбор не зависит от локали: десятичный разделитель всегда точка, а
+// значения хранятся точно, без потерь на float64, так что сравнение
+// величин во встраивающем коде совпадает со встроенными проверками.
+package quantity
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Множители суффиксов: двоичные (Ki, Mi, ...) и десятичные (m, k, M, ...)
+var suffixes = map[string]*big.Rat{
+	"Ki": pow(2, 10), "Mi": pow(2, 20), "Gi": pow(2, 30),
+	"Ti": pow(2, 40), "Pi": pow(2, 50), "Ei": pow(2, 60),
+	"n": pow(10, -9), "u": pow(10, -6), "m": pow(10, -3), "": pow(10, 0),
+	"k": pow(10, 3), "M": pow(10, 6), "G": pow(10, 9),
+	"T": pow(10, 12), "P": pow(10, 15), "E": pow(10, 18),
+}
+
+// Наибольший по модулю показатель экспоненты: как у суффиксов от n до E.
+// Больший показатель Kubernetes не представит, а вычисление 10^exp для
+// огромных значений из недоверенного ввода заняло бы неограниченное время.
+const maxExponent = 18
+
+// base в степени exp
+func pow(base, exp int64) *big.Rat {
+	n := new(big.Int).Exp(big.NewInt(base), big.NewInt(abs(exp)), nil)
+	if exp < 0 {
+		return new(big.Rat).SetFrac(big.NewInt(1), n)
+	}
+	return new(big.Rat).SetInt(n)
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Quantity — точное значение величины ресурса в базовых единицах
+// (ядрах для cpu, байтах для memory). Нулевое значение равно 0.
+type Quantity struct {
+	value *big.Rat
+	text  string
+}
+
+// Parse разбирает величину в формате Kubernetes: число с необязательной
+// дробной частью и суффиксом (двоичным, десятичным или экспонентой).
+func Parse(s string) (Quantity, error) {
+	text := strings.TrimSpace(s)
+
+	// Числовая часть: знак, цифры и не больше одной точки
+	end := 0
+	if end < len(text) && (text[end] == '+' || text[end] == '-') {
+		end++
+	}
+	digits, dots := 0, 0
+	for ; end < len(text); end++ {
+		c := text[end]
+		if c == '.' {
+			dots++
+		} else if c >= '0' && c <= '9' {
+			digits++
+		} else {
+			break
+		}
+	}
+	if digits == 0 || dots > 1 {
+		return Quantity{}, fmt.Errorf("invalid quantity '%s'", s)
+	}
+
+	value, ok := new(big.Rat).SetString(text[:end])
+	if !ok {
+		return Quantity{}, fmt.Errorf("invalid quantity '%s'", s)
+	}
+
+	suffix := text[end:]
+	if factor, ok := suffixes[suffix]; ok {
+		value.Mul(value, factor)
+	} else if suffix[0] == 'e' || suffix[0] == 'E' {
+		exp, err := strconv.ParseInt(suffix[1:], 10, 32)
+		if err != nil {
+			return Quantity{}, fmt.Errorf("invalid quantity '%s'", s)
+		}
+		if abs(exp) > maxExponent {
+			return Quantity{}, fmt.Errorf("invalid quantity '%s': exponent out of range %d..%d", s, -maxExponent, maxExponent)
+		}
+		value.Mul(value, pow(10, exp))
+	} else {
+		return Quantity{}, fmt.Errorf("invalid quantity '%s': unknown suffix '%s'", s, suffix)
+	}
+	return Quantity{value: value, text: text}, nil
+}
+
+func (q Quantity) rat() *big.Rat {
+	if q.value == nil {
+		return new(big.Rat)
+	}
+	return q.value
+}
+
+// Cmp сравнивает величины: -1, если q < other, 0 при равенстве, +1 иначе.
+func (q Quantity) Cmp(other Quantity) int {
+	return q.rat().Cmp(other.rat())
+}
+
+// Add возвращает сумму q и other.
+func (q Quantity) Add(other Quantity) Quantity {
+	return Quantity{value: new(big.Rat).Add(q.rat(), other.rat())}
+}
+
+// Sign возвращает -1, 0 или +1 в зависимости от знака величины.
+func (q Quantity) Sign() int {
+	return q.rat().Sign()
+}
+
+// Value возвращает величину в базовых единицах, округлённую вверх.
+func (q Quantity) Value() int64 {
+	return ceil(q.rat())
+}
+
+// MilliValue возвращает величину в тысячных долях (милиядрах для cpu),
+// округлённую вверх.
+func (q Quantity) MilliValue() int64 {
+	return ceil(new(big.Rat).Mul(q.rat(), pow(10, 3)))
+}
+
+// Округление вверх до целого; значения вне int64 ограничиваются его пределами
+func ceil(r *big.Rat) int64 {
+	n, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() > 0 {
+		n.Add(n, big.NewInt(1))
+	}
+	switch {
+	case !n.IsInt64() && n.Sign() > 0:
+		return math.MaxInt64
+	case !n.IsInt64():
+		return math.MinInt64
+	}
+	return n.Int64()
+}
+
+// String возвращает величину в исходной записи, а для вычисленных
+// величин — десятичную запись в базовых единицах.
+func (q Quantity) String() string {
+	if q.text != "" {
+		return q.text
+	}
+	if q.rat().IsInt() {
+		return q.rat().Num().String()
+	}
+	text := strings.TrimRight(q.rat().FloatString(9), "0")
+	return strings.TrimSuffix(text, ".")
+}
+
+// ParseDuration разбирает длительность: целое число секунд ("30"), как в
+// полях *Seconds, или запись Go ("1m30s", "500ms").
+func ParseDuration(s string) (time.Duration, error) {
+	text := strings.TrimSpace(s)
+	if seconds, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	d, err := time.ParseDuration(text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration '%s'", s)
+	}
+	return d, nil
+}
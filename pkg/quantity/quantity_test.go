@@ -0,0 +1,195 @@
+package quantity
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseHugeExponent(t *testing.T) {
+	done := make(chan error, 1)
+	go func() {
+		_, err := Parse("1e2000000000")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("Parse(1e2000000000): expected error")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Parse(1e2000000000) did not return within 1s")
+	}
+
+	for _, s := range []string{"1e-2000000000", "1e19", "1E-19"} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q): expected error", s)
+		}
+	}
+	for _, s := range []string{"1e18", "1e-18"} {
+		if _, err := Parse(s); err != nil {
+			t.Errorf("Parse(%q): %v", s, err)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in    string
+		value int64
+		str   string
+	}{
+		{"100m", 1, "100m"},
+		{"1Gi", 1 << 30, "1Gi"},
+		{"1e3", 1000, "1e3"},
+		{"1E3", 1000, "1E3"},
+		{"0.5", 1, "0.5"},
+		{"1.5Gi", 3 << 29, "1.5Gi"},
+		{"128M", 128000000, "128M"},
+		{"2", 2, "2"},
+		{" 3k ", 3000, "3k"},
+		{"-1", -1, "-1"},
+		{"+1Ki", 1024, "+1Ki"},
+		{"1e-3", 1, "1e-3"},
+		{".5", 1, ".5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			q, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.in, err)
+			}
+			if got := q.Value(); got != tt.value {
+				t.Errorf("Value() = %d, want %d", got, tt.value)
+			}
+			if got := q.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, in := range []string{
+		"", " ", "m", "Gi", "abc", "1.2.3", "1..0",
+		"1mi", "1GB", "1Kib", "1 Gi", "1e", "1e+", "1ee3", "1e3.5",
+		"1e99999999999", "1e19",
+	} {
+		if q, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %v, want error", in, q)
+		}
+	}
+}
+
+func TestCmp(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"100m", "0.1", 0},
+		{"1Gi", "1G", 1},
+		{"1e3", "1k", 0},
+		{"500m", "1", -1},
+		{"1024Ki", "1Mi", 0},
+	}
+	for _, tt := range tests {
+		a, b := mustParse(t, tt.a), mustParse(t, tt.b)
+		if got := a.Cmp(b); got != tt.want {
+			t.Errorf("Cmp(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestAdd(t *testing.T) {
+	sum := mustParse(t, "250m").Add(mustParse(t, "0.5")).Add(mustParse(t, "1"))
+	if got := sum.String(); got != "1.75" {
+		t.Errorf("String() = %q, want %q", got, "1.75")
+	}
+	if got := (Quantity{}).Add(mustParse(t, "1Ki")).String(); got != "1024" {
+		t.Errorf("String() = %q, want %q", got, "1024")
+	}
+	if (Quantity{}).Sign() != 0 {
+		t.Error("zero Quantity: Sign() != 0")
+	}
+}
+
+func TestValueOverflow(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"16Ei", math.MaxInt64},
+		{"1000E", math.MaxInt64},
+		{"-16Ei", math.MinInt64},
+		{"99999999999999999999", math.MaxInt64},
+		{"8Ei", math.MaxInt64},
+		{"7Ei", 7 << 60},
+	}
+	for _, tt := range tests {
+		if got := mustParse(t, tt.in).Value(); got != tt.want {
+			t.Errorf("Parse(%q).Value() = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMilliValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"100m", 100},
+		{"0.5", 500},
+		{"2", 2000},
+		{"1.5m", 2},
+		{"1n", 1},
+		{"-250m", -250},
+		{"1Ki", 1024000},
+		{"10E", math.MaxInt64},
+	}
+	for _, tt := range tests {
+		if got := mustParse(t, tt.in).MilliValue(); got != tt.want {
+			t.Errorf("Parse(%q).MilliValue() = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+	if got := (Quantity{}).MilliValue(); got != 0 {
+		t.Errorf("zero Quantity: MilliValue() = %d, want 0", got)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"30", 30 * time.Second},
+		{"0", 0},
+		{" 5 ", 5 * time.Second},
+		{"1m30s", 90 * time.Second},
+		{"500ms", 500 * time.Millisecond},
+		{"1.5h", 90 * time.Minute},
+	}
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.in)
+		if err != nil {
+			t.Errorf("ParseDuration(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	for _, in := range []string{"", "abc", "30x", "1,5s", "1.5"} {
+		if d, err := ParseDuration(in); err == nil {
+			t.Errorf("ParseDuration(%q) = %v, want error", in, d)
+		}
+	}
+}
+
+func mustParse(t *testing.T, s string) Quantity {
+	t.Helper()
+	q, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", s, err)
+	}
+	return q
+}
@@ -0,0 +1,583 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"main.go/pkg/podlint"
+)
+
+// Результат проверки одного файла. Деревья документов после проверки не
+// хранятся, чтобы память при пакетной проверке не росла с числом файлов.
+type fileResult struct {
+	file     string
+	docs     []docInfo // nil, если файл не прочитан или не разобран
+	findings []podlint.ValidationError
+	data     []byte // содержимое файла, только для вывода фрагментов (--show-source)
+
+	// Нарушения, подавленные первопричиной; только с --show-suppressed
+	suppressed []podlint.ValidationError
+
+	// Отключённые правила каждого проверенного документа, для --require-rules
+	disabled []map[string]bool
+}
+
+// Сведения о документе, нужные отчётам
+type docInfo struct {
+	index                  int
+	kind, name, apiVersion string
+}
+
+// Сведения о документах файла
+func describeDocs(docs []*podlint.Document) []docInfo {
+	if docs == nil {
+		return nil
+	}
+	infos := make([]docInfo, len(docs))
+	for i, doc := range docs {
+		infos[i] = docInfo{index: doc.Index, kind: doc.Kind(), name: doc.Name(), apiVersion: doc.APIVersion()}
+	}
+	return infos
+}
+
+// Количество ошибок и предупреждений в результате
+func (res fileResult) counts() (errors, warnings int) {
+	for _, f := range res.findings {
+		if f.Severity == podlint.SeverityWarning {
+			warnings++
+		} else {
+			errors++
+		}
+	}
+	return errors, warnings
+}
+
+// Не прошёл ли файл проверку: есть нарушения уровня failOn или серьёзнее
+// (failOn — error, warning или none, как у --fail-on)
+func (res fileResult) failed(failOn string) bool {
+	errors, warnings := res.counts()
+	switch failOn {
+	case "none":
+		return false
+	case podlint.SeverityWarning:
+		return errors+warnings > 0
+	default:
+		return errors > 0
+	}
+}
+
+// Текстовый отчёт: "<файл>:<строка> <сообщение>" (с колонкой при column),
+// предупреждения помечаются "warning:". При source под нарушением выводится
+// строка файла с указателем на поле. При summary — итоги по файлам с
+// полными путями, иначе файл выводится по базовому имени; файл не прошёл
+// проверку при нарушениях уровня failOn
+func writeText(w io.Writer, results []fileResult, failOn string, summary, column, source bool) error {
+	printed := false // выведено ли что-то до итогов
+	for _, res := range results {
+		var lines []string
+		if source {
+			lines = strings.Split(string(res.data), "\n")
+		}
+		for _, f := range res.findings {
+			printed = true
+			name := f.File
+			if !summary {
+				name = filepath.Base(name)
+			}
+			msg := f.Message
+			if f.Severity == podlint.SeverityWarning {
+				msg = "warning: " + msg
+			}
+			var err error
+			switch {
+			case f.RuleID == podlint.RuleInput:
+				_, err = fmt.Fprintf(w, "%s: %s\n", name, msg)
+			case column:
+				_, err = fmt.Fprintf(w, "%s:%d:%d %s\n", name, f.Line, f.Column, msg)
+			default:
+				_, err = fmt.Fprintf(w, "%s:%d %s\n", name, f.Line, msg)
+			}
+			if err == nil && source {
+				err = writeSnippet(w, lines, f.Line, f.Column)
+			}
+			// Связанные места — с отступом под нарушением
+			for _, rel := range f.Related {
+				if err != nil {
+					break
+				}
+				if column {
+					_, err = fmt.Fprintf(w, "  %s:%d:%d %s\n", name, rel.Line, rel.Column, rel.Message)
+				} else {
+					_, err = fmt.Fprintf(w, "  %s:%d %s\n", name, rel.Line, rel.Message)
+				}
+				if err == nil && source {
+					err = writeSnippet(w, lines, rel.Line, rel.Column)
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+		for _, f := range res.suppressed {
+			printed = true
+			name := f.File
+			if !summary {
+				name = filepath.Base(name)
+			}
+			if _, err := fmt.Fprintf(w, "%s:%d suppressed by %s: %s\n", name, f.Line, f.SuppressedBy, f.Message); err != nil {
+				return err
+			}
+		}
+	}
+	if !summary {
+		return nil
+	}
+
+	failed := 0
+	if printed {
+		fmt.Fprintln(w)
+	}
+	for _, res := range results {
+		errors, warnings := res.counts()
+		status := "WARN"
+		if res.failed(failOn) {
+			failed++
+			status = "FAIL"
+		}
+		switch {
+		case errors > 0 && warnings > 0:
+			fmt.Fprintf(w, "%s: %s (%d errors, %d warnings)\n", res.file, status, errors, warnings)
+		case errors > 0:
+			fmt.Fprintf(w, "%s: %s (%d errors)\n", res.file, status, errors)
+		case warnings > 0:
+			fmt.Fprintf(w, "%s: %s (%d warnings)\n", res.file, status, warnings)
+		default:
+			fmt.Fprintf(w, "%s: OK\n", res.file)
+		}
+	}
+	var err error
+	if failed > 0 {
+		_, err = fmt.Fprintf(w, "FAIL: %d of %d files failed validation\n", failed, len(results))
+	} else {
+		_, err = fmt.Fprintf(w, "PASS: %d files validated\n", len(results))
+	}
+	return err
+}
+
+// Строка файла line с указателем "^" под колонкой column, как в
+// диагностике компиляторов
+func writeSnippet(w io.Writer, lines []string, line, column int) error {
+	if line < 1 || line > len(lines) || column < 1 {
+		return nil
+	}
+	text := strings.TrimRight(lines[line-1], "\r")
+	number := strconv.Itoa(line)
+	// Табуляции сохраняются, чтобы указатель стоял под тем же символом
+	// column считается в символах, а не в байтах
+	var indent strings.Builder
+	i := 0
+	for _, c := range text {
+		if i++; i >= column {
+			break
+		}
+		if c == '\t' {
+			indent.WriteByte('\t')
+		} else {
+			indent.WriteByte(' ')
+		}
+	}
+	_, err := fmt.Fprintf(w, "  %s | %s\n  %s | %s^\n", number, text, strings.Repeat(" ", len(number)), indent.String())
+	return err
+}
+
+// Сколько примеров нарушений выводится для каждого правила при группировке
+const groupSamples = 5
+
+// Текстовый отчёт, сгруппированный по правилам: число нарушений и файлов
+// для каждого правила и несколько примеров, чаще нарушаемые правила первыми
+func writeGrouped(w io.Writer, results []fileResult, failOn string, column bool) error {
+	type group struct {
+		rule     string
+		severity string
+		findings []podlint.ValidationError
+		files    map[string]bool
+	}
+	groups := make(map[string]*group)
+	var order []*group
+	for _, res := range results {
+		for _, f := range res.findings {
+			g, ok := groups[f.RuleID]
+			if !ok {
+				g = &group{rule: f.RuleID, severity: f.Severity, files: make(map[string]bool)}
+				groups[f.RuleID] = g
+				order = append(order, g)
+			}
+			g.findings = append(g.findings, f)
+			g.files[res.file] = true
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if len(order[i].findings) != len(order[j].findings) {
+			return len(order[i].findings) > len(order[j].findings)
+		}
+		return order[i].rule < order[j].rule
+	})
+
+	for _, g := range order {
+		fmt.Fprintf(w, "%s: %d occurrences across %d files (%s)\n", g.rule, len(g.findings), len(g.files), g.severity)
+		if ri, ok := podlint.LookupRule(g.rule); ok {
+			fmt.Fprintf(w, "  %s\n", ri.Description)
+		}
+		for i, f := range g.findings {
+			if i == groupSamples {
+				fmt.Fprintf(w, "  ... and %d more\n", len(g.findings)-groupSamples)
+				break
+			}
+			switch {
+			case f.Line == 0:
+				fmt.Fprintf(w, "  %s: %s\n", f.File, f.Message)
+			case column:
+				fmt.Fprintf(w, "  %s:%d:%d %s\n", f.File, f.Line, f.Column, f.Message)
+			default:
+				fmt.Fprintf(w, "  %s:%d %s\n", f.File, f.Line, f.Message)
+			}
+		}
+	}
+
+	report := newJSONReport(results, failOn)
+	var err error
+	if report.Summary.Failed > 0 {
+		_, err = fmt.Fprintf(w, "FAIL: %d of %d files failed validation\n", report.Summary.Failed, report.Summary.Files)
+	} else {
+		_, err = fmt.Fprintf(w, "PASS: %d files validated\n", report.Summary.Files)
+	}
+	return err
+}
+
+// Итоги проверки
+type reportSummary struct {
+	Files    int `json:"files"`
+	Failed   int `json:"failed"` // файлы, не прошедшие проверку (см. fileResult.failed)
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+}
+
+// Структура JSON-отчёта, она же тело уведомления на webhook
+type jsonReport struct {
+	Valid    bool                      `json:"valid"`
+	Summary  reportSummary             `json:"summary"`
+	Findings []podlint.ValidationError `json:"findings"`
+
+	// Подавленные первопричиной нарушения, только с --show-suppressed
+	Suppressed []podlint.ValidationError `json:"suppressed,omitempty"`
+}
+
+// Отчёт по результатам; файл не прошёл проверку при нарушениях уровня failOn
+func newJSONReport(results []fileResult, failOn string) jsonReport {
+	report := jsonReport{Findings: []podlint.ValidationError{}}
+	for _, res := range results {
+		errors, warnings := res.counts()
+		report.Summary.Files++
+		if res.failed(failOn) {
+			report.Summary.Failed++
+		}
+		report.Summary.Errors += errors
+		report.Summary.Warnings += warnings
+		report.Findings = append(report.Findings, res.findings...)
+		report.Suppressed = append(report.Suppressed, res.suppressed...)
+	}
+	report.Valid = report.Summary.Failed == 0
+	return report
+}
+
+// Отчёт в формате JSON для CI и других инструментов
+func writeJSON(w io.Writer, results []fileResult, failOn string) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(newJSONReport(results, failOn))
+}
+
+// Количество нарушений по уровням серьёзности
+type severityCounts struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+}
+
+func (c *severityCounts) add(severity string) {
+	if severity == podlint.SeverityWarning {
+		c.Warnings++
+	} else {
+		c.Errors++
+	}
+}
+
+// Сводный JSON-отчёт без отдельных нарушений: итоги и число нарушений по
+// правилам и файлам, для дашбордов по большим проверкам
+func writeJSONSummary(w io.Writer, results []fileResult, failOn string) error {
+	full := newJSONReport(results, failOn)
+	report := struct {
+		Valid   bool                       `json:"valid"`
+		Summary reportSummary              `json:"summary"`
+		Rules   map[string]*severityCounts `json:"rules"`
+		Files   map[string]*severityCounts `json:"files"`
+	}{
+		Valid:   full.Valid,
+		Summary: full.Summary,
+		Rules:   make(map[string]*severityCounts),
+		Files:   make(map[string]*severityCounts),
+	}
+	for _, res := range results {
+		file := &severityCounts{}
+		report.Files[res.file] = file
+		for _, f := range res.findings {
+			file.add(f.Severity)
+			rule, ok := report.Rules[f.RuleID]
+			if !ok {
+				rule = &severityCounts{}
+				report.Rules[f.RuleID] = rule
+			}
+			rule.add(f.Severity)
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// Отчёт SARIF 2.1.0 для GitHub code scanning и других SARIF-совместимых систем
+func writeSARIF(w io.Writer, results []fileResult) error {
+	type message struct {
+		Text string `json:"text"`
+	}
+	type configuration struct {
+		Level string `json:"level"`
+	}
+	type rule struct {
+		ID                   string        `json:"id"`
+		ShortDescription     message       `json:"shortDescription"`
+		DefaultConfiguration configuration `json:"defaultConfiguration"`
+	}
+	type region struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+	}
+	type artifactLocation struct {
+		URI string `json:"uri"`
+	}
+	type physicalLocation struct {
+		ArtifactLocation artifactLocation `json:"artifactLocation"`
+		Region           *region          `json:"region,omitempty"`
+	}
+	type location struct {
+		ID               int              `json:"id,omitempty"`
+		PhysicalLocation physicalLocation `json:"physicalLocation"`
+		Message          *message         `json:"message,omitempty"`
+	}
+	type result struct {
+		RuleID           string     `json:"ruleId"`
+		Level            string     `json:"level"`
+		Message          message    `json:"message"`
+		Locations        []location `json:"locations"`
+		RelatedLocations []location `json:"relatedLocations,omitempty"`
+	}
+	type driver struct {
+		Name  string `json:"name"`
+		Rules []rule `json:"rules"`
+	}
+	type tool struct {
+		Driver driver `json:"driver"`
+	}
+	type run struct {
+		Tool    tool     `json:"tool"`
+		Results []result `json:"results"`
+	}
+
+	r := run{Tool: tool{Driver: driver{Name: "yamlvalid"}}, Results: []result{}}
+	for _, ri := range podlint.Rules {
+		r.Tool.Driver.Rules = append(r.Tool.Driver.Rules, rule{
+			ID:                   ri.ID,
+			ShortDescription:     message{ri.Description},
+			DefaultConfiguration: configuration{ri.Severity},
+		})
+	}
+	for _, res := range results {
+		for _, f := range res.findings {
+			loc := physicalLocation{ArtifactLocation: artifactLocation{URI: filepath.ToSlash(f.File)}}
+			if f.Line > 0 {
+				loc.Region = &region{StartLine: f.Line, StartColumn: f.Column}
+			}
+			text := f.Message
+			if f.FieldPath != "" {
+				text = f.FieldPath + ": " + text
+			}
+			var related []location
+			for i, rel := range f.Related {
+				relLoc := physicalLocation{ArtifactLocation: loc.ArtifactLocation}
+				if rel.Line > 0 {
+					relLoc.Region = &region{StartLine: rel.Line, StartColumn: rel.Column}
+				}
+				related = append(related, location{ID: i + 1, PhysicalLocation: relLoc, Message: &message{rel.Message}})
+			}
+			r.Results = append(r.Results, result{
+				RuleID:           f.RuleID,
+				Level:            f.Severity,
+				Message:          message{text},
+				Locations:        []location{{PhysicalLocation: loc}},
+				RelatedLocations: related,
+			})
+		}
+	}
+
+	report := struct {
+		Schema  string `json:"$schema"`
+		Version string `json:"version"`
+		Runs    []run  `json:"runs"`
+	}{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []run{r},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// Отчёт JUnit XML: каждый файл — тест, каждое нарушение — failure
+func writeJUnit(w io.Writer, results []fileResult) error {
+	type failure struct {
+		Message string `xml:"message,attr"`
+		Type    string `xml:"type,attr"`
+		Text    string `xml:",chardata"`
+	}
+	type testCase struct {
+		Name      string    `xml:"name,attr"`
+		ClassName string    `xml:"classname,attr"`
+		Failures  []failure `xml:"failure"`
+		SystemOut string    `xml:"system-out,omitempty"` // предупреждения
+	}
+	type testSuite struct {
+		Name      string     `xml:"name,attr"`
+		Tests     int        `xml:"tests,attr"`
+		Failures  int        `xml:"failures,attr"`
+		TestCases []testCase `xml:"testcase"`
+	}
+	type testSuites struct {
+		XMLName  xml.Name    `xml:"testsuites"`
+		Name     string      `xml:"name,attr"`
+		Tests    int         `xml:"tests,attr"`
+		Failures int         `xml:"failures,attr"`
+		Suites   []testSuite `xml:"testsuite"`
+	}
+
+	suite := testSuite{Name: "yamlvalid"}
+	for _, res := range results {
+		tc := testCase{Name: res.file, ClassName: "yamlvalid"}
+		for _, f := range res.findings {
+			text := fmt.Sprintf("%s: %s", f.File, f.Message)
+			if f.Line > 0 {
+				text = fmt.Sprintf("%s:%d:%d %s", f.File, f.Line, f.Column, f.Message)
+			}
+			if f.FieldPath != "" {
+				text += " (" + f.FieldPath + ")"
+			}
+			if f.Severity == podlint.SeverityWarning {
+				tc.SystemOut += "warning: " + text + "\n"
+				continue
+			}
+			tc.Failures = append(tc.Failures, failure{Message: f.Message, Type: f.RuleID, Text: text})
+		}
+		suite.Tests++
+		if len(tc.Failures) > 0 {
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	report := testSuites{Name: "yamlvalid", Tests: suite.Tests, Failures: suite.Failures, Suites: []testSuite{suite}}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// Отчёт в формате "kubeconform -output json" для существующих парсеров CI.
+// Как и kubeconform без -verbose, перечисляет только непрошедшие ресурсы.
+func writeKubeconformJSON(w io.Writer, results []fileResult, opts podlint.Options) error {
+	type validationError struct {
+		Path string `json:"path"`
+		Msg  string `json:"msg"`
+	}
+	type resource struct {
+		Filename         string            `json:"filename"`
+		Kind             string            `json:"kind"`
+		Name             string            `json:"name"`
+		Version          string            `json:"version"`
+		Status           string            `json:"status"`
+		Msg              string            `json:"msg"`
+		ValidationErrors []validationError `json:"validationErrors,omitempty"`
+	}
+	type summary struct {
+		Valid   int `json:"valid"`
+		Invalid int `json:"invalid"`
+		Errors  int `json:"errors"`
+		Skipped int `json:"skipped"`
+	}
+	report := struct {
+		Resources []resource `json:"resources"`
+		Summary   summary    `json:"summary"`
+	}{Resources: []resource{}}
+
+	for _, res := range results {
+		// Файл, который не удалось прочитать или разобрать
+		if res.docs == nil && len(res.findings) > 0 {
+			report.Summary.Errors++
+			report.Resources = append(report.Resources, resource{
+				Filename: res.file,
+				Status:   "statusError",
+				Msg:      res.findings[0].Message,
+			})
+			continue
+		}
+		// Предупреждения в kubeconform не выразить, ресурс с ними считается корректным
+		for _, doc := range res.docs {
+			r := resource{Filename: res.file, Kind: doc.kind, Name: doc.name, Version: doc.apiVersion}
+			var msgs []string
+			for _, f := range res.findings {
+				if f.Document != doc.index || f.Severity == podlint.SeverityWarning {
+					continue
+				}
+				msgs = append(msgs, f.Message)
+				r.ValidationErrors = append(r.ValidationErrors, validationError{Path: f.JSONPointer(), Msg: f.Message})
+			}
+			switch {
+			case opts.SkipsKind(doc.kind):
+				report.Summary.Skipped++
+			case len(msgs) == 0:
+				report.Summary.Valid++
+			default:
+				report.Summary.Invalid++
+				r.Status = "statusInvalid"
+				r.Msg = strings.Join(msgs, " - ")
+				report.Resources = append(report.Resources, r)
+			}
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
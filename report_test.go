@@ -0,0 +1,440 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"main.go/pkg/podlint"
+)
+
+func TestWriteTextSeparator(t *testing.T) {
+	finding := podlint.ValidationError{File: "a.yaml", Line: 3, RuleID: podlint.RuleImageTag, Message: "image has invalid format 'nginx'", Severity: podlint.SeverityError}
+	tests := []struct {
+		name    string
+		results []fileResult
+		want    string
+	}{
+		{"no findings", []fileResult{{file: "a.yaml"}, {file: "b.yaml"}},
+			"a.yaml: OK\nb.yaml: OK\nPASS: 2 files validated\n"},
+		{"findings", []fileResult{{file: "a.yaml", findings: []podlint.ValidationError{finding}}, {file: "b.yaml"}},
+			"a.yaml:3 image has invalid format 'nginx'\n\na.yaml: FAIL (1 errors)\nb.yaml: OK\nFAIL: 1 of 2 files failed validation\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := writeText(&out, tt.results, podlint.SeverityError, true, false, false); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteSnippet(t *testing.T) {
+	lines := []string{"kind: Pod", "  labels: {\"я\": x, app: -web-}", "\timage: nginx"}
+	tests := []struct {
+		line, column int
+		want         string
+	}{
+		{1, 7, "  1 | kind: Pod\n    |       ^\n"},
+		{2, 25, "  2 |   labels: {\"я\": x, app: -web-}\n    |                         ^\n"},
+		{3, 9, "  3 | \timage: nginx\n    | \t       ^\n"},
+		{4, 1, ""},
+	}
+	for _, tt := range tests {
+		var out strings.Builder
+		if err := writeSnippet(&out, lines, tt.line, tt.column); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != tt.want {
+			t.Errorf("%d:%d: got:\n%s\nwant:\n%s", tt.line, tt.column, out.String(), tt.want)
+		}
+	}
+}
+
+// Итоги текстового и JSON-отчётов совпадают с кодом выхода (shouldFail)
+func TestFailOnSummary(t *testing.T) {
+	warning := podlint.ValidationError{File: "a.yaml", Line: 3, RuleID: podlint.RuleImageLatest, Message: "image tag 'latest' is not allowed", Severity: podlint.SeverityWarning}
+	results := []fileResult{{file: "a.yaml", findings: []podlint.ValidationError{warning}}, {file: "b.yaml"}}
+	tests := []struct {
+		failOn  string
+		summary string
+		valid   bool
+	}{
+		{podlint.SeverityError, "a.yaml: WARN (1 warnings)\nb.yaml: OK\nPASS: 2 files validated\n", true},
+		{podlint.SeverityWarning, "a.yaml: FAIL (1 warnings)\nb.yaml: OK\nFAIL: 1 of 2 files failed validation\n", false},
+		{"none", "a.yaml: WARN (1 warnings)\nb.yaml: OK\nPASS: 2 files validated\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.failOn, func(t *testing.T) {
+			if shouldFail(results, tt.failOn) == tt.valid {
+				t.Fatalf("shouldFail = %v, want %v", tt.valid, !tt.valid)
+			}
+			var out strings.Builder
+			if err := writeText(&out, results, tt.failOn, true, false, false); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasSuffix(out.String(), "\n\n"+tt.summary) {
+				t.Errorf("text summary:\n%s\nwant:\n%s", out.String(), tt.summary)
+			}
+
+			var report jsonReport
+			var buf bytes.Buffer
+			if err := writeJSON(&buf, results, tt.failOn); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+				t.Fatal(err)
+			}
+			wantFailed := 0
+			if !tt.valid {
+				wantFailed = 1
+			}
+			if report.Valid != tt.valid || report.Summary.Failed != wantFailed || report.Summary.Warnings != 1 {
+				t.Errorf("json: valid %v, summary %+v; want valid %v, failed %d", report.Valid, report.Summary, tt.valid, wantFailed)
+			}
+		})
+	}
+}
+
+// Pod с повтором имени контейнера (ошибка со связанным местом) и тегом
+// latest (предупреждение при reportOptions)
+const duplicatePod = "apiVersion: v1\nkind: Pod\nmetadata:\n  name: web\nspec:\n  containers:\n" +
+	"    - name: web\n      image: web:latest\n    - name: web\n      image: web:1\n"
+
+func reportOptions() podlint.Options {
+	opts := podlint.DefaultOptions()
+	opts.DenyLatest = true
+	opts.Severities = map[string]string{podlint.RuleImageLatest: podlint.SeverityWarning}
+	return opts
+}
+
+// Результаты проверки файлов name → содержимое, в порядке names
+func lintFiles(t *testing.T, opts podlint.Options, files map[string]string, names ...string) []fileResult {
+	t.Helper()
+	dir := writeFiles(t, files)
+	var results []fileResult
+	for _, name := range names {
+		in := inputFile{name: filepath.Join(dir, name), source: fsSource{}}
+		res := validateFile(in, opts, false, false, false)
+		res.file = name
+		for i := range res.findings {
+			res.findings[i].File = name
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+func TestWriteSARIF(t *testing.T) {
+	results := lintFiles(t, reportOptions(), map[string]string{"dup.yaml": duplicatePod, "ok.yaml": testPod}, "dup.yaml", "ok.yaml")
+	var out bytes.Buffer
+	if err := writeSARIF(&out, results); err != nil {
+		t.Fatal(err)
+	}
+
+	type region struct{ StartLine, StartColumn int }
+	type location struct {
+		ID               int
+		PhysicalLocation struct {
+			ArtifactLocation struct{ URI string }
+			Region           *region
+		}
+		Message *struct{ Text string }
+	}
+	var report struct {
+		Schema  string `json:"$schema"`
+		Version string
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string
+					Rules []struct {
+						ID                   string
+						DefaultConfiguration struct{ Level string }
+					}
+				}
+			}
+			Results []struct {
+				RuleID           string
+				Level            string
+				Message          struct{ Text string }
+				Locations        []location
+				RelatedLocations []location
+			}
+		}
+	}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("%v\n%s", err, out.String())
+	}
+	if report.Version != "2.1.0" || report.Schema == "" || len(report.Runs) != 1 {
+		t.Fatalf("unexpected header: version %q, schema %q, %d runs", report.Version, report.Schema, len(report.Runs))
+	}
+	run := report.Runs[0]
+	if run.Tool.Driver.Name != "yamlvalid" || len(run.Tool.Driver.Rules) != len(podlint.Rules) {
+		t.Errorf("driver %q with %d rules, want yamlvalid with %d", run.Tool.Driver.Name, len(run.Tool.Driver.Rules), len(podlint.Rules))
+	}
+	for i, rule := range run.Tool.Driver.Rules {
+		if ri := podlint.Rules[i]; rule.ID != ri.ID || rule.DefaultConfiguration.Level != ri.Severity {
+			t.Errorf("rule %d: %s/%s, want %s/%s", i, rule.ID, rule.DefaultConfiguration.Level, ri.ID, ri.Severity)
+		}
+	}
+
+	type want struct {
+		rule, level string
+		line, col   int
+		related     []region
+	}
+	wants := []want{
+		{podlint.RuleContainerDup, "error", 9, 13, []region{{7, 13}}},
+		{podlint.RuleImageLatest, "warning", 8, 14, nil},
+	}
+	if len(run.Results) != len(wants) {
+		t.Fatalf("%d results, want %d:\n%s", len(run.Results), len(wants), out.String())
+	}
+	for _, w := range wants {
+		found := false
+		for _, r := range run.Results {
+			if r.RuleID != w.rule {
+				continue
+			}
+			found = true
+			loc := r.Locations[0].PhysicalLocation
+			if r.Level != w.level || loc.ArtifactLocation.URI != "dup.yaml" || loc.Region == nil ||
+				*loc.Region != (region{w.line, w.col}) {
+				t.Errorf("%s: level %s, %s at %+v; want %s, dup.yaml at %d:%d", w.rule, r.Level, loc.ArtifactLocation.URI, loc.Region, w.level, w.line, w.col)
+			}
+			if len(r.RelatedLocations) != len(w.related) {
+				t.Errorf("%s: %d related locations, want %d", w.rule, len(r.RelatedLocations), len(w.related))
+				continue
+			}
+			for i, rel := range r.RelatedLocations {
+				if rel.ID != i+1 || rel.Message == nil || rel.PhysicalLocation.Region == nil || *rel.PhysicalLocation.Region != w.related[i] {
+					t.Errorf("%s: related %d = %+v, want id %d at %+v", w.rule, i, rel, i+1, w.related[i])
+				}
+			}
+		}
+		if !found {
+			t.Errorf("no %s result", w.rule)
+		}
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	bad := strings.Replace(testPod, "web:1", "nginx", 1)
+	results := lintFiles(t, reportOptions(), map[string]string{"dup.yaml": duplicatePod, "bad.yaml": bad, "ok.yaml": testPod},
+		"dup.yaml", "bad.yaml", "ok.yaml")
+	var out bytes.Buffer
+	if err := writeJUnit(&out, results); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.String(), xml.Header) {
+		t.Errorf("missing XML header:\n%s", out.String())
+	}
+
+	type testCase struct {
+		Name     string `xml:"name,attr"`
+		Failures []struct {
+			Message string `xml:"message,attr"`
+			Type    string `xml:"type,attr"`
+			Text    string `xml:",chardata"`
+		} `xml:"failure"`
+		SystemOut string `xml:"system-out"`
+	}
+	var report struct {
+		XMLName  xml.Name `xml:"testsuites"`
+		Tests    int      `xml:"tests,attr"`
+		Failures int      `xml:"failures,attr"`
+		Suites   []struct {
+			Tests     int        `xml:"tests,attr"`
+			Failures  int        `xml:"failures,attr"`
+			TestCases []testCase `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("malformed XML: %v\n%s", err, out.String())
+	}
+	if report.Tests != 3 || report.Failures != 2 || len(report.Suites) != 1 {
+		t.Fatalf("testsuites: %d tests, %d failures, %d suites; want 3, 2, 1", report.Tests, report.Failures, len(report.Suites))
+	}
+	suite := report.Suites[0]
+	if suite.Tests != 3 || suite.Failures != 2 || len(suite.TestCases) != 3 {
+		t.Fatalf("testsuite: %d tests, %d failures, %d testcases; want 3, 2, 3", suite.Tests, suite.Failures, len(suite.TestCases))
+	}
+
+	tests := []struct {
+		name      string
+		failures  []string // type у failure
+		systemOut string   // часть system-out
+	}{
+		{"dup.yaml", []string{podlint.RuleContainerDup}, "warning: dup.yaml:8:14 image tag 'latest' is not allowed (spec.containers[0].image)"},
+		{"bad.yaml", []string{podlint.RuleImageTag}, ""},
+		{"ok.yaml", nil, ""},
+	}
+	for i, tt := range tests {
+		tc := suite.TestCases[i]
+		var types []string
+		for _, f := range tc.Failures {
+			types = append(types, f.Type)
+			if f.Message == "" || !strings.HasPrefix(f.Text, tt.name+":") {
+				t.Errorf("%s: failure %+v", tt.name, f)
+			}
+		}
+		if tc.Name != tt.name || strings.Join(types, ",") != strings.Join(tt.failures, ",") {
+			t.Errorf("testcase %d: %s with failures %v, want %s with %v", i, tc.Name, types, tt.name, tt.failures)
+		}
+		if !strings.Contains(tc.SystemOut, tt.systemOut) || (tt.systemOut == "") != (tc.SystemOut == "") {
+			t.Errorf("%s: system-out %q, want %q", tt.name, tc.SystemOut, tt.systemOut)
+		}
+	}
+}
+
+func TestWriteKubeconformJSON(t *testing.T) {
+	opts := reportOptions()
+	opts.SkipKinds = []string{"ConfigMap"}
+	labels := strings.Replace(testPod, "  name: web\n", "  name: bad\n  labels:\n    app.kubernetes.io/name: -web-\n", 1) +
+		"---\n" + testPod + "---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cfg\n"
+	warn := strings.Replace(testPod, "web:1", "web:latest", 1)
+	results := lintFiles(t, opts, map[string]string{"labels.yaml": labels, "broken.yaml": "kind: [", "warn.yaml": warn},
+		"labels.yaml", "broken.yaml", "warn.yaml")
+	var out bytes.Buffer
+	if err := writeKubeconformJSON(&out, results, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	var report struct {
+		Resources []struct {
+			Filename, Kind, Name, Version, Status, Msg string
+			ValidationErrors                           []struct{ Path, Msg string }
+		}
+		Summary struct{ Valid, Invalid, Errors, Skipped int }
+	}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("%v\n%s", err, out.String())
+	}
+	// Корректные и пропущенные ресурсы в списке не выводятся
+	if s := report.Summary; s.Valid != 2 || s.Invalid != 1 || s.Errors != 1 || s.Skipped != 1 {
+		t.Errorf("summary %+v, want 2 valid, 1 invalid, 1 error, 1 skipped", s)
+	}
+	if len(report.Resources) != 2 {
+		t.Fatalf("%d resources, want 2:\n%s", len(report.Resources), out.String())
+	}
+
+	invalid := report.Resources[0]
+	if invalid.Filename != "labels.yaml" || invalid.Kind != "Pod" || invalid.Name != "bad" || invalid.Version != "v1" ||
+		invalid.Status != "statusInvalid" || invalid.Msg == "" {
+		t.Errorf("invalid resource %+v", invalid)
+	}
+	if len(invalid.ValidationErrors) != 1 || invalid.ValidationErrors[0].Path != "/metadata/labels/app.kubernetes.io~1name" {
+		t.Errorf("validationErrors %+v, want path /metadata/labels/app.kubernetes.io~1name", invalid.ValidationErrors)
+	}
+
+	broken := report.Resources[1]
+	if broken.Filename != "broken.yaml" || broken.Status != "statusError" || broken.Msg == "" || broken.ValidationErrors != nil {
+		t.Errorf("broken resource %+v", broken)
+	}
+}
+
+func TestWriteGrouped(t *testing.T) {
+	finding := func(file string, line int, rule, severity string) podlint.ValidationError {
+		return podlint.ValidationError{File: file, Line: line, Column: 9, RuleID: rule, Message: "message " + rule, Severity: severity}
+	}
+	var a, b []podlint.ValidationError
+	for line := 1; line <= 4; line++ {
+		a = append(a, finding("a.yaml", line, podlint.RuleImageTag, podlint.SeverityError))
+	}
+	for line := 1; line <= 3; line++ {
+		b = append(b, finding("b.yaml", line, podlint.RuleImageTag, podlint.SeverityError))
+	}
+	// Поровну нарушений: порядок по идентификатору правила
+	b = append(b, finding("b.yaml", 9, podlint.RulePortRange, podlint.SeverityError), finding("b.yaml", 0, podlint.RuleImageLatest, podlint.SeverityWarning))
+	results := []fileResult{{file: "a.yaml", findings: a}, {file: "b.yaml", findings: b}, {file: "c.yaml"}}
+
+	describe := func(rule string) string {
+		ri, _ := podlint.LookupRule(rule)
+		return ri.Description
+	}
+	want := "IMG002: 7 occurrences across 2 files (error)\n  " + describe(podlint.RuleImageTag) + "\n" +
+		"  a.yaml:1 message IMG002\n  a.yaml:2 message IMG002\n  a.yaml:3 message IMG002\n  a.yaml:4 message IMG002\n" +
+		"  b.yaml:1 message IMG002\n  ... and 2 more\n" +
+		"IMG004: 1 occurrences across 1 files (warning)\n  " + describe(podlint.RuleImageLatest) + "\n" +
+		"  b.yaml: message IMG004\n" +
+		podlint.RulePortRange + ": 1 occurrences across 1 files (error)\n  " + describe(podlint.RulePortRange) + "\n" +
+		"  b.yaml:9 message " + podlint.RulePortRange + "\n" +
+		"FAIL: 2 of 3 files failed validation\n"
+	var out strings.Builder
+	if err := writeGrouped(&out, results, podlint.SeverityError, false); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := writeGrouped(&out, results[2:], podlint.SeverityError, true); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "PASS: 1 files validated\n" {
+		t.Errorf("no findings: got %q", out.String())
+	}
+
+	// С column — позиция file:line:column
+	out.Reset()
+	if err := writeGrouped(&out, results[:1], podlint.SeverityError, true); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "  a.yaml:1:9 message IMG002\n") {
+		t.Errorf("column: got:\n%s", out.String())
+	}
+}
+
+func TestWriteJSONSummary(t *testing.T) {
+	bad := strings.Replace(testPod, "web:1", "nginx", 1)
+	results := lintFiles(t, reportOptions(), map[string]string{"dup.yaml": duplicatePod, "bad.yaml": bad, "ok.yaml": testPod},
+		"dup.yaml", "bad.yaml", "ok.yaml")
+	var out bytes.Buffer
+	if err := writeJSONSummary(&out, results, podlint.SeverityError); err != nil {
+		t.Fatal(err)
+	}
+
+	var report struct {
+		Valid    bool
+		Summary  reportSummary
+		Rules    map[string]severityCounts
+		Files    map[string]severityCounts
+		Findings json.RawMessage
+	}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("%v\n%s", err, out.String())
+	}
+	if report.Findings != nil {
+		t.Errorf("summary contains findings: %s", report.Findings)
+	}
+	if report.Valid || report.Summary != (reportSummary{Files: 3, Failed: 2, Errors: 2, Warnings: 1}) {
+		t.Errorf("valid %v, summary %+v", report.Valid, report.Summary)
+	}
+	wantRules := map[string]severityCounts{
+		podlint.RuleContainerDup: {Errors: 1},
+		podlint.RuleImageTag:     {Errors: 1},
+		podlint.RuleImageLatest:  {Warnings: 1},
+	}
+	if !reflect.DeepEqual(report.Rules, wantRules) {
+		t.Errorf("rules %+v, want %+v", report.Rules, wantRules)
+	}
+	// Файлы без нарушений тоже перечисляются, с нулями
+	wantFiles := map[string]severityCounts{
+		"dup.yaml": {Errors: 1, Warnings: 1},
+		"bad.yaml": {Errors: 1},
+		"ok.yaml":  {},
+	}
+	if !reflect.DeepEqual(report.Files, wantFiles) {
+		t.Errorf("files %+v, want %+v", report.Files, wantFiles)
+	}
+}
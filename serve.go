@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"main.go/pkg/podlint"
+)
+
+// Подкоманда serve: HTTP API для сервисов, которым неудобно запускать
+// бинарник. POST /validate принимает манифест в YAML или JSON и возвращает
+// JSON-отчёт, как --output json; /healthz — проверка доступности.
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	config := fs.String("config", "", "configuration file (default "+podlint.DefaultConfigFile+" in the current directory, if present)")
+	env := fs.String("env", "", "environment from the environments block of the configuration, e.g. prod")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Println("Usage: yamlvalid serve [--addr :8080] [--config <file>] [--env <name>]")
+		return 2
+	}
+
+	opts := podlint.DefaultOptions()
+	if err := loadConfig(*config, *env, &opts); err != nil {
+		fmt.Printf("config: %v\n", err)
+		return 2
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/validate", validateHandler(opts))
+	mux.HandleFunc("/healthz", healthz)
+	server := &http.Server{Addr: *addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	fmt.Printf("serving validation API on %s\n", *addr)
+	if err := server.ListenAndServe(); err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	return 0
+}
+
+// Ответ на проверку доступности сервера
+func healthz(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintln(w, "ok")
+}
+
+// Обработчик POST /validate. Имя файла в отчёте задаётся параметром name,
+// по умолчанию "request"; по нему работают overrides и layout из конфигурации.
+func validateHandler(opts podlint.Options) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body := r.Body
+		if opts.MaxFileSize > 0 {
+			// На байт больше лимита, чтобы превышение сообщил сам podlint
+			body = http.MaxBytesReader(w, r.Body, opts.MaxFileSize+1)
+		}
+		data, err := io.ReadAll(body)
+		if err != nil {
+			status := http.StatusBadRequest
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			http.Error(w, fmt.Sprintf("unable to read request body: %v", err), status)
+			return
+		}
+
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			name = "request"
+		}
+		res := podlint.ValidateFile(name, data, opts)
+		report := newJSONReport([]fileResult{{file: name, findings: res.Errors}}, podlint.SeverityError)
+
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(report)
+	}
+}
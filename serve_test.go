@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"main.go/pkg/podlint"
+)
+
+func TestValidateHandler(t *testing.T) {
+	opts := podlint.DefaultOptions()
+	opts.MaxFileSize = 1024
+	jsonPod := `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"web"},"spec":{"containers":[{"name":"web","image":"web:1"}]}}`
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		valid  bool
+		rules  []string // правила нарушений в отчёте
+		file   string   // File нарушений
+	}{
+		{"yaml", http.MethodPost, "/validate", testPod, http.StatusOK, true, nil, ""},
+		{"json", http.MethodPost, "/validate", jsonPod, http.StatusOK, true, nil, ""},
+		{"invalid manifest", http.MethodPost, "/validate?name=pod.yaml", strings.Replace(testPod, "web:1", "nginx", 1),
+			http.StatusOK, false, []string{podlint.RuleImageTag}, "pod.yaml"},
+		{"default name", http.MethodPost, "/validate", "kind: [", http.StatusOK, false, []string{podlint.RuleInput}, "request"},
+		{"at the limit", http.MethodPost, "/validate", strings.Repeat("#", 1025), http.StatusOK, false, []string{podlint.RuleInput}, "request"},
+		{"oversize", http.MethodPost, "/validate", strings.Repeat("#", 4096), http.StatusRequestEntityTooLarge, false, nil, ""},
+		{"get", http.MethodGet, "/validate", "", http.StatusMethodNotAllowed, false, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			validateHandler(opts)(rec, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+			if rec.Code != tt.status {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type %q", ct)
+			}
+			var report jsonReport
+			if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+				t.Fatalf("response: %v\n%s", err, rec.Body)
+			}
+			var rules []string
+			for _, f := range report.Findings {
+				rules = append(rules, f.RuleID)
+				if f.File != tt.file {
+					t.Errorf("finding file %q, want %q", f.File, tt.file)
+				}
+			}
+			if report.Valid != tt.valid || strings.Join(rules, ",") != strings.Join(tt.rules, ",") {
+				t.Errorf("valid %v, rules %v; want %v, %v", report.Valid, rules, tt.valid, tt.rules)
+			}
+		})
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	rec := httptest.NewRecorder()
+	healthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "ok\n" {
+		t.Errorf("status %d, body %q", rec.Code, rec.Body)
+	}
+}
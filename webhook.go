@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"main.go/pkg/podlint"
+)
+
+// Подкоманда serve-webhook: проверка ресурсов при создании и изменении в
+// кластере как ValidatingAdmissionWebhook (AdmissionReview v1 по HTTPS).
+// Ресурс с ошибками отклоняется, предупреждения возвращаются в warnings.
+func runServeWebhook(args []string) int {
+	fs := flag.NewFlagSet("serve-webhook", flag.ContinueOnError)
+	addr := fs.String("addr", ":8443", "address to listen on")
+	cert := fs.String("tls-cert", "", "TLS certificate file (PEM)")
+	key := fs.String("tls-key", "", "TLS private key file (PEM)")
+	config := fs.String("config", "", "configuration file (default "+podlint.DefaultConfigFile+" in the current directory, if present)")
+	env := fs.String("env", "", "environment from the environments block of the configuration, e.g. prod")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *cert == "" || *key == "" {
+		fmt.Println("Usage: yamlvalid serve-webhook --tls-cert <file> --tls-key <file> [--addr :8443] [--config <file>]")
+		return 2
+	}
+
+	opts := podlint.DefaultOptions()
+	if err := loadConfig(*config, *env, &opts); err != nil {
+		fmt.Printf("config: %v\n", err)
+		return 2
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/validate", admissionHandler(opts))
+	mux.HandleFunc("/healthz", healthz)
+	server := &http.Server{Addr: *addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	fmt.Printf("serving admission webhook on %s\n", *addr)
+	if err := server.ListenAndServeTLS(*cert, *key); err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	return 0
+}
+
+// AdmissionReview admission.k8s.io/v1, только используемые поля
+type admissionReview struct {
+	APIVersion string             `json:"apiVersion"`
+	Kind       string             `json:"kind"`
+	Request    *admissionRequest  `json:"request,omitempty"`
+	Response   *admissionResponse `json:"response,omitempty"`
+}
+
+type admissionRequest struct {
+	UID       string          `json:"uid"`
+	Namespace string          `json:"namespace,omitempty"`
+	Operation string          `json:"operation"`
+	Object    json.RawMessage `json:"object,omitempty"`
+}
+
+type admissionResponse struct {
+	UID      string           `json:"uid"`
+	Allowed  bool             `json:"allowed"`
+	Status   *admissionStatus `json:"status,omitempty"`
+	Warnings []string         `json:"warnings,omitempty"`
+}
+
+type admissionStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Обработчик AdmissionReview: проверка request.object с параметрами opts
+func admissionHandler(opts podlint.Options) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if opts.MaxFileSize > 0 {
+			r.Body = http.MaxBytesReader(w, r.Body, opts.MaxFileSize)
+		}
+		var review admissionReview
+		if err := json.NewDecoder(r.Body).Decode(&review); err != nil || review.Request == nil {
+			http.Error(w, "expected an AdmissionReview with a request", http.StatusBadRequest)
+			return
+		}
+
+		review.Response = reviewObject(review.Request, opts)
+		review.Request = nil
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(review)
+	}
+}
+
+// Решение по объекту запроса. При DELETE проверять нечего, запрос
+// пропускается; объект, который не удалось прочитать, отклоняется.
+func reviewObject(req *admissionRequest, opts podlint.Options) *admissionResponse {
+	resp := &admissionResponse{UID: req.UID, Allowed: true}
+	if req.Operation == "DELETE" {
+		return resp
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(req.Object, &object); err != nil || object == nil {
+		return deny(resp, http.StatusBadRequest, "request.object is missing or is not a JSON object")
+	}
+
+	// Пространство имён приходит в запросе, в самом объекте его может не быть;
+	// оно нужно для overrides и наборов правил по пространствам имён
+	metadata, _ := object["metadata"].(map[string]interface{})
+	if metadata != nil && metadata["namespace"] == nil && req.Namespace != "" {
+		metadata["namespace"] = req.Namespace
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		return deny(resp, http.StatusInternalServerError, fmt.Sprintf("unable to encode request.object: %v", err))
+	}
+
+	var denied []string
+	for _, f := range podlint.ValidateBytes("", data, opts) {
+		msg := f.Message
+		if f.FieldPath != "" {
+			msg = f.FieldPath + ": " + msg
+		}
+		msg = "[" + f.RuleID + "] " + msg
+		if f.Severity == podlint.SeverityWarning {
+			resp.Warnings = append(resp.Warnings, msg)
+			continue
+		}
+		denied = append(denied, msg)
+	}
+	if len(denied) > 0 {
+		return deny(resp, http.StatusForbidden, strings.Join(denied, "; "))
+	}
+	return resp
+}
+
+// Отказ с HTTP-кодом code и сообщением msg
+func deny(resp *admissionResponse, code int, msg string) *admissionResponse {
+	resp.Allowed = false
+	resp.Status = &admissionStatus{Code: code, Message: msg}
+	return resp
+}
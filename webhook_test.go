@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"main.go/pkg/podlint"
+)
+
+// Отправка AdmissionReview с request.object = object (JSON) обработчику
+func postReview(t *testing.T, opts podlint.Options, operation, object string) (int, admissionReview) {
+	t.Helper()
+	body := `{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview","request":{"uid":"uid-1","operation":"` + operation + `"`
+	if object != "" {
+		body += `,"object":` + object
+	}
+	body += `}}`
+	rec := httptest.NewRecorder()
+	admissionHandler(opts)(rec, httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(body)))
+
+	var review admissionReview
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &review); err != nil {
+			t.Fatalf("response: %v\n%s", err, rec.Body)
+		}
+	}
+	return rec.Code, review
+}
+
+// Pod web с образом %q
+const admissionPod = `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"web","namespace":"default"},` +
+	`"spec":{"containers":[{"name":"web","image":%q}]}}`
+
+func TestAdmissionReview(t *testing.T) {
+	opts := podlint.DefaultOptions()
+	opts.DenyLatest = true
+	opts.Severities = map[string]string{podlint.RuleImageLatest: podlint.SeverityWarning}
+
+	tests := []struct {
+		name      string
+		operation string
+		object    string
+		allowed   bool
+		code      int    // status.code отказа
+		message   string // часть status.message
+		warnings  int
+	}{
+		{"valid", "CREATE", fmt.Sprintf(admissionPod, "web:1.0"), true, 0, "", 0},
+		{"warnings", "CREATE", fmt.Sprintf(admissionPod, "web:latest"), true, 0, "", 1},
+		{"errors", "UPDATE", fmt.Sprintf(admissionPod, "nginx"), false, http.StatusForbidden, "[" + podlint.RuleImageTag + "]", 0},
+		{"null object", "CREATE", "null", false, http.StatusBadRequest, "request.object", 0},
+		{"missing object", "CREATE", "", false, http.StatusBadRequest, "request.object", 0},
+		{"not an object", "CREATE", `"pod"`, false, http.StatusBadRequest, "request.object", 0},
+		{"delete", "DELETE", "", true, 0, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, review := postReview(t, opts, tt.operation, tt.object)
+			if code != http.StatusOK {
+				t.Fatalf("HTTP status %d, want 200", code)
+			}
+			resp := review.Response
+			if resp == nil || review.Request != nil {
+				t.Fatalf("expected a response without the request, got %+v", review)
+			}
+			if resp.UID != "uid-1" {
+				t.Errorf("uid %q, want %q", resp.UID, "uid-1")
+			}
+			if resp.Allowed != tt.allowed {
+				t.Errorf("allowed = %v, want %v (status %+v)", resp.Allowed, tt.allowed, resp.Status)
+			}
+			if tt.allowed && resp.Status != nil {
+				t.Errorf("unexpected status %+v", resp.Status)
+			}
+			if !tt.allowed && (resp.Status == nil || resp.Status.Code != tt.code || !strings.Contains(resp.Status.Message, tt.message)) {
+				t.Errorf("status %+v, want code %d with %q", resp.Status, tt.code, tt.message)
+			}
+			if len(resp.Warnings) != tt.warnings {
+				t.Errorf("warnings %v, want %d", resp.Warnings, tt.warnings)
+			}
+		})
+	}
+}
+
+func TestAdmissionHandlerBadRequest(t *testing.T) {
+	opts := podlint.DefaultOptions()
+	for name, req := range map[string]*http.Request{
+		"get":        httptest.NewRequest(http.MethodGet, "/validate", nil),
+		"not json":   httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader("kind: Pod")),
+		"no request": httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(`{"kind":"AdmissionReview"}`)),
+	} {
+		rec := httptest.NewRecorder()
+		admissionHandler(opts)(rec, req)
+		if rec.Code == http.StatusOK {
+			t.Errorf("%s: status 200, want an error", name)
+		}
+	}
+}